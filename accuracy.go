@@ -0,0 +1,197 @@
+package geojson2h3
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+// earthRadiusMeters is the authalic radius of the Earth used by H3.
+const earthRadiusMeters = 6371007.180918475
+
+// Accuracy is the error of the hexagon approximation of a GeoJSON polygon objects.
+// The areas are in square meters, the distances are in meters.
+type Accuracy struct {
+	// Area of the polygons.
+	Area float64
+	// CellsArea is the area of the hexagons.
+	CellsArea float64
+	// FalsePositiveArea is the area of the hexagons outside the polygons.
+	FalsePositiveArea float64
+	// FalseNegativeArea is the area of the polygons outside the hexagons.
+	FalseNegativeArea float64
+	// BoundaryDistance is the Hausdorff distance between the polygon rings
+	// and the outline of the hexagons.
+	BoundaryDistance float64
+}
+
+// ToH3Accuracy measures how the hexagons approximate a GeoJSON polygon objects,
+// e.g. the result of ToH3 or ToH3Adaptive.
+//
+// Known list of objects:
+//  - Polygon, MultiPolygon, Rect, Circle
+//  - GeometryCollection, Feature, FeatureCollection of the objects above
+//
+// The polygons must not overlap each other, as well as the hexagons
+// of mixed resolutions. The boundary distance is measured on the rings
+// densified to a quarter of the hexagon edge length of the finest resolution.
+func ToH3Accuracy(o geojson.Object, indexes []h3.H3Index) (Accuracy, error) {
+	if o == nil {
+		return Accuracy{}, fmt.Errorf("geojson.Object is nil")
+	}
+	if len(indexes) == 0 {
+		return Accuracy{}, fmt.Errorf("uber h3 indexes are empty")
+	}
+	cells := sortCells(indexes)
+	resolution := 0
+	for _, index := range cells {
+		if !h3.IsValid(index) {
+			return Accuracy{}, fmt.Errorf("got invalid h3 index %x", uint64(index))
+		}
+		if r := h3.Resolution(index); r > resolution {
+			resolution = r
+		}
+	}
+	polygons := make([]*geojson.Polygon, 0, 1)
+	if err := collectPolygons(o, &polygons); err != nil {
+		return Accuracy{}, err
+	}
+
+	var accuracy Accuracy
+	for _, polygon := range polygons {
+		accuracy.Area += polygonArea(polygon)
+	}
+	cover := newPolygonCover(polygons)
+	intersection := float64(0)
+	for _, index := range cells {
+		area := h3.CellAreaM2(index)
+		accuracy.CellsArea += area
+		intersection += area * cover.fraction(index)
+	}
+	accuracy.FalsePositiveArea = math.Max(0, accuracy.CellsArea-intersection)
+	accuracy.FalseNegativeArea = math.Max(0, accuracy.Area-intersection)
+
+	fine, err := h3.Uncompact(cells, resolution)
+	if err != nil {
+		return Accuracy{}, err
+	}
+	step := h3.EdgeLengthM(resolution) / 4
+	outline := cellsOutline(fine)
+	boundary := make([]geometry.Segment, 0)
+	for _, rings := range cover.rings {
+		for _, ring := range rings {
+			for i := 0; i < ring.NumSegments(); i++ {
+				boundary = append(boundary, ring.SegmentAt(i))
+			}
+		}
+	}
+	accuracy.BoundaryDistance = math.Max(
+		directedHausdorff(boundary, outline, step),
+		directedHausdorff(outline, boundary, step),
+	)
+	return accuracy, nil
+}
+
+// polygonArea returns the area of the polygon in square meters,
+// measured in the sinusoidal projection centered at the polygon.
+func polygonArea(polygon *geojson.Polygon) float64 {
+	center := polygon.Center()
+	project := func(p geometry.Point) geometry.Point {
+		lat := p.Y * math.Pi / 180
+		return geometry.Point{
+			X: (p.X - center.X) * math.Pi / 180 * math.Cos(lat) * earthRadiusMeters,
+			Y: lat * earthRadiusMeters,
+		}
+	}
+	ringMeters := func(ring geometry.Ring) float64 {
+		points := make([]geometry.Point, 0, ring.NumPoints())
+		for i := 0; i < ring.NumPoints(); i++ {
+			points = append(points, project(ring.PointAt(i)))
+		}
+		return math.Abs(ringArea(points))
+	}
+	area := ringMeters(polygon.Base().Exterior)
+	for _, hole := range polygon.Base().Holes {
+		area -= ringMeters(hole)
+	}
+	return math.Max(0, area)
+}
+
+// cellsOutline returns the edges between the hexagons of the set
+// and their neighbours outside the set.
+func cellsOutline(indexes []h3.H3Index) []geometry.Segment {
+	set := make(map[h3.H3Index]struct{}, len(indexes))
+	for _, index := range indexes {
+		set[index] = struct{}{}
+	}
+	segments := make([]geometry.Segment, 0)
+	for _, index := range indexes {
+		for _, edge := range h3.ToUnidirectionalEdges(index) {
+			if _, ok := set[h3.DestinationFromUnidirectionalEdge(edge)]; ok {
+				continue
+			}
+			boundary := h3.UnidirectionalEdgeBoundary(edge)
+			for i := 1; i < len(boundary); i++ {
+				segments = append(segments, geometry.Segment{
+					A: geometry.Point{X: boundary[i-1].Longitude, Y: boundary[i-1].Latitude},
+					B: geometry.Point{X: boundary[i].Longitude, Y: boundary[i].Latitude},
+				})
+			}
+		}
+	}
+	return segments
+}
+
+// maxHausdorffSamples limits the number of samples per segment.
+const maxHausdorffSamples = 1024
+
+// directedHausdorff returns the largest distance in meters from the points
+// of the segments a, sampled every step meters, to the nearest segment of b.
+func directedHausdorff(a, b []geometry.Segment, step float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	max := float64(0)
+	for _, segment := range a {
+		n := int(math.Ceil(distanceMeters(segment) / step))
+		if n < 1 {
+			n = 1
+		}
+		if n > maxHausdorffSamples {
+			n = maxHausdorffSamples
+		}
+		for i := 0; i <= n; i++ {
+			t := float64(i) / float64(n)
+			p := geometry.Point{
+				X: segment.A.X + (segment.B.X-segment.A.X)*t,
+				Y: segment.A.Y + (segment.B.Y-segment.A.Y)*t,
+			}
+			min := math.Inf(1)
+			for _, s := range b {
+				min = math.Min(min, pointSegmentMeters(p, s))
+				if min <= max {
+					break
+				}
+			}
+			max = math.Max(max, min)
+		}
+	}
+	return max
+}
+
+// pointSegmentMeters returns the distance in meters from the point to the segment,
+// measured in the equirectangular projection centered at the point.
+func pointSegmentMeters(p geometry.Point, s geometry.Segment) float64 {
+	scale := math.Cos(p.Y*math.Pi/180) * math.Pi / 180 * earthRadiusMeters
+	ax, ay := (s.A.X-p.X)*scale, (s.A.Y-p.Y)*math.Pi/180*earthRadiusMeters
+	bx, by := (s.B.X-p.X)*scale, (s.B.Y-p.Y)*math.Pi/180*earthRadiusMeters
+	dx, dy := bx-ax, by-ay
+	t := float64(0)
+	if l := dx*dx + dy*dy; l > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/l))
+	}
+	return math.Hypot(ax+dx*t, ay+dy*t)
+}
@@ -0,0 +1,94 @@
+package geojson2h3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestToH3Accuracy(t *testing.T) {
+	points := strToPoints(`
+[-74.0, 40.7],
+[-73.9, 40.7],
+[-73.9, 40.8],
+[-74.0, 40.7]
+`)
+	polygon := geojson.NewPolygon(geometry.NewPoly(points, nil, nil))
+	var prev Accuracy
+	for _, resolution := range []int{7, 9} {
+		indexes, err := ToH3(resolution, polygon)
+		if err != nil {
+			t.Fatal(err)
+		}
+		have, err := ToH3Accuracy(polygon, indexes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 0.1 x 0.1 degrees triangle at 40.75 latitude
+		want := 0.1 * 0.1 / 2 * math.Cos(40.75*math.Pi/180) * math.Pow(earthRadiusMeters*math.Pi/180, 2)
+		if math.Abs(have.Area-want) > want*0.01 {
+			t.Fatalf("have %f, want %f", have.Area, want)
+		}
+		if math.Abs(have.CellsArea-have.Area) > have.Area*0.2 {
+			t.Fatalf("have %f, want about %f", have.CellsArea, have.Area)
+		}
+		if have.FalsePositiveArea <= 0 || have.FalseNegativeArea <= 0 {
+			t.Fatalf("have %f and %f, want > 0", have.FalsePositiveArea, have.FalseNegativeArea)
+		}
+		if have.CellsArea-have.FalsePositiveArea+have.FalseNegativeArea-have.Area > 1e-6*have.Area {
+			t.Fatalf("have inconsistent areas %+v", have)
+		}
+		if edge := h3.EdgeLengthM(resolution); have.BoundaryDistance <= 0 || have.BoundaryDistance > 3*edge {
+			t.Fatalf("have %f, want (0, %f]", have.BoundaryDistance, 3*edge)
+		}
+		if resolution > 7 {
+			if have.FalsePositiveArea+have.FalseNegativeArea >= prev.FalsePositiveArea+prev.FalseNegativeArea {
+				t.Fatalf("have %+v, want less error than %+v", have, prev)
+			}
+			if have.BoundaryDistance >= prev.BoundaryDistance {
+				t.Fatalf("have %f, want < %f", have.BoundaryDistance, prev.BoundaryDistance)
+			}
+		}
+		prev = have
+	}
+}
+
+func TestCellToH3Accuracy(t *testing.T) {
+	index := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 7)
+	polygon := geojson.NewPolygon(geometry.NewPoly(cellBoundary(index), nil, nil))
+	have, err := ToH3Accuracy(polygon, []h3.H3Index{index})
+	if err != nil {
+		t.Fatal(err)
+	}
+	area := h3.CellAreaM2(index)
+	if math.Abs(have.Area-area) > area*0.01 {
+		t.Fatalf("have %f, want %f", have.Area, area)
+	}
+	if have.FalsePositiveArea > area*0.01 || have.FalseNegativeArea > area*0.01 {
+		t.Fatalf("have %f and %f, want about 0", have.FalsePositiveArea, have.FalseNegativeArea)
+	}
+	if have.BoundaryDistance > 1 {
+		t.Fatalf("have %f, want about 0", have.BoundaryDistance)
+	}
+}
+
+func TestInvalidToH3Accuracy(t *testing.T) {
+	index := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 7)
+	polygon := geojson.NewPolygon(geometry.NewPoly(cellBoundary(index), nil, nil))
+	if _, err := ToH3Accuracy(nil, []h3.H3Index{index}); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := ToH3Accuracy(polygon, nil); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := ToH3Accuracy(polygon, []h3.H3Index{0}); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	point := geojson.NewPoint(geometry.Point{X: -73.95, Y: 40.75})
+	if _, err := ToH3Accuracy(point, []h3.H3Index{index}); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
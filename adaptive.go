@@ -0,0 +1,222 @@
+package geojson2h3
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+// ToH3Adaptive converts a GeoJSON polygon objects to a mixed-resolution list of hexagons,
+// from minResolution to maxResolution, with at most maxCells hexagons (zero means no limit).
+// See Converter.ToH3Adaptive for the details.
+func ToH3Adaptive(minResolution, maxResolution, maxCells int, o geojson.Object) ([]h3.H3Index, error) {
+	c := Converter{Resolution: maxResolution, MaxCells: maxCells}
+	return c.ToH3Adaptive(minResolution, o)
+}
+
+// ToH3Adaptive converts a GeoJSON polygon objects to a mixed-resolution list of hexagons:
+// the interior is filled with the coarsest hexagons fully within the polygons,
+// the boundary is refined from minResolution down to the converter resolution.
+//
+// Known list of objects:
+//  - Polygon, MultiPolygon, Rect, Circle
+//  - GeometryCollection, Feature, FeatureCollection of the objects above
+//
+// MaxCells limits the number of hexagons: the refinement stops at the
+// resolution which keeps the result within the limit, the boundary hexagons
+// of that resolution are all included. The error is returned when
+// the hexagons of minResolution exceed the limit.
+// The Containment selects the boundary hexagons of the converter resolution.
+// The Compact, Fallback and Perimeter options are not applied, the result is sorted.
+func (c *Converter) ToH3Adaptive(minResolution int, o geojson.Object) ([]h3.H3Index, error) {
+	if o == nil {
+		return nil, fmt.Errorf("geojson.Object is nil")
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if minResolution < 0 || minResolution > c.Resolution {
+		return nil, fmt.Errorf("got invalid resolution range %d..%d. expected 0 <= min <= max <= 15",
+			minResolution, c.Resolution)
+	}
+	polygons := make([]*geojson.Polygon, 0, 1)
+	if err := collectPolygons(o, &polygons); err != nil {
+		return nil, err
+	}
+	cover := newPolygonCover(polygons)
+
+	coverage := make(map[h3.H3Index]float64)
+	for _, polygon := range polygons {
+		if err := polygonToH3Coverage(minResolution, polygon, coverage); err != nil {
+			return nil, err
+		}
+	}
+	indexes := make([]h3.H3Index, 0, len(coverage))
+	boundary := make([]h3.H3Index, 0, len(coverage))
+	for index, fraction := range coverage {
+		switch {
+		case fraction >= fullCoverage:
+			indexes = append(indexes, index)
+		case fraction > 0:
+			boundary = append(boundary, index)
+		}
+	}
+	if c.MaxCells > 0 && len(indexes)+len(boundary) > c.MaxCells {
+		return nil, fmt.Errorf("%w. got %d hexagons at resolution %d, expected <= %d hexagons", ErrMaxCells,
+			len(indexes)+len(boundary), minResolution, c.MaxCells)
+	}
+	interior := make(map[h3.H3Index]struct{}, len(indexes))
+	for _, index := range indexes {
+		interior[index] = struct{}{}
+	}
+
+	resolution := minResolution
+	for ; resolution < c.Resolution && len(boundary) > 0; resolution++ {
+		full, next := cover.refine(boundary, resolution+1, interior, minResolution)
+		if c.MaxCells > 0 && len(indexes)+len(full)+len(next) > c.MaxCells {
+			break
+		}
+		for _, index := range full {
+			interior[index] = struct{}{}
+		}
+		indexes = append(indexes, full...)
+		boundary = next
+	}
+	for _, index := range boundary {
+		if resolution == c.Resolution && !cover.contains(index, c.Containment) {
+			continue
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes, nil
+}
+
+func collectPolygons(o geojson.Object, polygons *[]*geojson.Polygon) error {
+	return forEachGeometry(o, func(geom geojson.Object, _ *geojson.Feature) error {
+		return collectPolygon(geom, polygons)
+	})
+}
+
+func collectPolygon(o geojson.Object, polygons *[]*geojson.Polygon) (err error) {
+	switch typ := o.(type) {
+	case *geojson.MultiPolygon:
+		typ.ForEach(func(geom geojson.Object) bool {
+			err = collectPolygon(geom, polygons)
+			return err == nil
+		})
+	case *geojson.Polygon:
+		*polygons = append(*polygons, typ)
+	case *geojson.Rect:
+		*polygons = append(*polygons, rectToPolygon(typ))
+	case *geojson.Circle:
+		var polygon *geojson.Polygon
+		polygon, err = circleToPolygon(typ)
+		if err == nil {
+			*polygons = append(*polygons, polygon)
+		}
+	default:
+		err = fmt.Errorf("expected geojson.Polygon, geojson.MultiPolygon, geojson.Rect or geojson.Circle, got %T", o)
+	}
+	return
+}
+
+// polygonCover computes the coverage of the hexagons by a set of polygons.
+type polygonCover struct {
+	polygons []*geojson.Polygon
+	rings    [][]geometry.Ring
+	rects    []geometry.Rect
+}
+
+func newPolygonCover(polygons []*geojson.Polygon) *polygonCover {
+	cover := &polygonCover{
+		polygons: polygons,
+		rings:    make([][]geometry.Ring, 0, len(polygons)),
+		rects:    make([]geometry.Rect, 0, len(polygons)),
+	}
+	for _, polygon := range polygons {
+		rings := make([]geometry.Ring, 0, 1+len(polygon.Base().Holes))
+		rings = append(rings, polygon.Base().Exterior)
+		rings = append(rings, polygon.Base().Holes...)
+		cover.rings = append(cover.rings, rings)
+		cover.rects = append(cover.rects, polygon.Rect())
+	}
+	return cover
+}
+
+// refine splits the boundary hexagons into the hexagons of the resolution,
+// returning the hexagons fully within the polygons and the new boundary hexagons.
+// The neighbours of the children are checked as well, since the children
+// only approximately cover their parent.
+func (cover *polygonCover) refine(boundary []h3.H3Index, resolution int,
+	interior map[h3.H3Index]struct{}, minResolution int) (full, next []h3.H3Index) {
+	visits := make(map[h3.H3Index]struct{}, len(boundary)*7)
+	for _, parent := range boundary {
+		for _, child := range h3.ToChildren(parent, resolution) {
+			for _, index := range h3.KRing(child, 1) {
+				if _, ok := visits[index]; ok {
+					continue
+				}
+				visits[index] = struct{}{}
+				if hasAncestor(index, interior, minResolution) {
+					continue
+				}
+				fraction := cover.fraction(index)
+				switch {
+				case fraction >= fullCoverage:
+					full = append(full, index)
+				case fraction > 0:
+					next = append(next, index)
+				}
+			}
+		}
+	}
+	return full, next
+}
+
+// fraction returns the fraction of the hexagon area covered by the polygons.
+func (cover *polygonCover) fraction(index h3.H3Index) float64 {
+	rect := cellRect(index)
+	fraction := float64(0)
+	for i, rings := range cover.rings {
+		if !cover.rects[i].IntersectsRect(rect) {
+			continue
+		}
+		fraction += cellCoverage(index, rings)
+	}
+	return math.Min(1, fraction)
+}
+
+// contains reports whether the boundary hexagon is selected by the containment.
+func (cover *polygonCover) contains(index h3.H3Index, containment Containment) bool {
+	switch containment {
+	case ContainmentIntersects:
+		return true
+	case ContainmentFull:
+		return false
+	default:
+		center := h3.ToGeo(index)
+		point := geometry.Point{X: center.Longitude, Y: center.Latitude}
+		for _, polygon := range cover.polygons {
+			if polygon.Base().ContainsPoint(point) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// hasAncestor reports whether any ancestor of the hexagon
+// down to the minResolution is in the set.
+func hasAncestor(index h3.H3Index, set map[h3.H3Index]struct{}, minResolution int) bool {
+	for resolution := h3.Resolution(index) - 1; resolution >= minResolution; resolution-- {
+		if _, ok := set[h3.ToParent(index, resolution)]; ok {
+			return true
+		}
+	}
+	return false
+}
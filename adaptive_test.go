@@ -0,0 +1,124 @@
+package geojson2h3
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestPolygonToH3Adaptive(t *testing.T) {
+	points := strToPoints(`
+[-74.2, 40.5],
+[-73.7, 40.5],
+[-73.7, 40.9],
+[-74.2, 40.5]
+`)
+	polygon := geojson.NewPolygon(geometry.NewPoly(points, nil, nil))
+	indexes, err := ToH3Adaptive(5, 9, 0, polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fine, err := ToH3(9, polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indexes) >= len(fine) {
+		t.Fatalf("have %d, want < %d", len(indexes), len(fine))
+	}
+	set := make(map[h3.H3Index]struct{}, len(indexes))
+	resolutions := make(map[int]int)
+	for _, index := range indexes {
+		if hasAncestor(index, set, 5) {
+			t.Fatalf("have %s with ancestor, want no overlaps", h3.ToString(index))
+		}
+		set[index] = struct{}{}
+		resolutions[h3.Resolution(index)]++
+	}
+	if resolutions[9] == 0 || len(resolutions) < 2 {
+		t.Fatalf("have %v, want mixed resolutions down to 9", resolutions)
+	}
+	// the hexagons with centers within the polygon are covered
+	missed := 0
+	for _, index := range fine {
+		if _, ok := set[index]; ok {
+			continue
+		}
+		if !hasAncestor(index, set, 5) {
+			missed++
+		}
+	}
+	if missed > len(fine)/100 {
+		t.Fatalf("have %d missed hexagons, want <= %d", missed, len(fine)/100)
+	}
+}
+
+func TestMaxCellsToH3Adaptive(t *testing.T) {
+	points := strToPoints(`
+[-74.2, 40.5],
+[-73.7, 40.5],
+[-73.7, 40.9],
+[-74.2, 40.5]
+`)
+	polygon := geojson.NewPolygon(geometry.NewPoly(points, nil, nil))
+	unlimited, err := ToH3Adaptive(5, 9, 0, polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	maxCells := len(unlimited) / 4
+	indexes, err := ToH3Adaptive(5, 9, maxCells, polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indexes) > maxCells {
+		t.Fatalf("have %d, want <= %d", len(indexes), maxCells)
+	}
+	for _, index := range indexes {
+		if h3.Resolution(index) == 9 {
+			t.Fatalf("have resolution 9, want coarser boundary")
+		}
+	}
+	if _, err := ToH3Adaptive(5, 9, 1, polygon); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
+
+func TestContainmentToH3Adaptive(t *testing.T) {
+	rect := geojson.NewRect(geometry.Rect{
+		Min: geometry.Point{X: -74.0, Y: 40.7},
+		Max: geometry.Point{X: -73.9, Y: 40.8},
+	})
+	counts := make([]int, 0, 3)
+	for _, containment := range []Containment{ContainmentFull, ContainmentCenter, ContainmentIntersects} {
+		conv := &Converter{Resolution: 9, Containment: containment}
+		indexes, err := conv.ToH3Adaptive(6, rect)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts = append(counts, len(indexes))
+	}
+	if !(counts[0] < counts[1] && counts[1] < counts[2]) {
+		t.Fatalf("have %v, want full < center < intersects", counts)
+	}
+}
+
+func TestInvalidToH3Adaptive(t *testing.T) {
+	point := geojson.NewPoint(geometry.Point{X: -74.0, Y: 40.7})
+	if _, err := ToH3Adaptive(5, 9, 0, point); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := ToH3Adaptive(5, 9, 0, nil); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	rect := geojson.NewRect(geometry.Rect{
+		Min: geometry.Point{X: -74.0, Y: 40.7},
+		Max: geometry.Point{X: -73.9, Y: 40.8},
+	})
+	ranges := [][2]int{{-1, 5}, {6, 5}, {5, 16}}
+	for _, r := range ranges {
+		if _, err := ToH3Adaptive(r[0], r[1], 0, rect); err == nil {
+			t.Fatalf("have nil, want error for %d..%d", r[0], r[1])
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package geojson2h3
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/tidwall/gjson"
+	"github.com/uber/h3-go/v3"
+)
+
+// AltitudeCell is a hexagon with an altitude band.
+type AltitudeCell struct {
+	Index h3.H3Index
+	// Band is the index of the altitude band, see ToH3Altitude.
+	Band int
+}
+
+// ToH3Altitude converts a GeoJSON point and line objects with Z coordinates
+// to a list of hexagons with specified resolution and altitude bands.
+// See Converter.ToH3Altitude for the details.
+func ToH3Altitude(resolution int, o geojson.Object, bands []float64) ([]AltitudeCell, error) {
+	c := Converter{Resolution: resolution}
+	return c.ToH3Altitude(o, bands)
+}
+
+// ToH3Altitude converts a GeoJSON point and line objects with Z coordinates
+// to a list of unique hexagons with altitude bands, in the order of the coordinates.
+//
+// Known list of objects:
+//  - Point, MultiPoint
+//  - LineString, MultiLineString
+//  - GeometryCollection, Feature, FeatureCollection of the objects above
+//
+// The bands are the ascending boundaries of the altitude bands: the band
+// of the altitude z is the number of boundaries <= z, so it is 0 below bands[0]
+// and len(bands) at or above the last boundary. The lines are sampled every
+// LineStep meters, like ToH3 does, with the altitude interpolated between
+// the vertices. Every coordinate must have the Z value, except a Point
+// without one is at the altitude 0, as reported by geojson.Point.Z.
+// The hexagons with different bands are counted apart by MaxCells.
+func (c *Converter) ToH3Altitude(o geojson.Object, bands []float64) ([]AltitudeCell, error) {
+	if o == nil {
+		return nil, fmt.Errorf("geojson.Object is nil")
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if !sort.Float64sAreSorted(bands) {
+		return nil, fmt.Errorf("got unsorted altitude bands, expected ascending bands")
+	}
+	a := altitudeCells{
+		resolution: c.Resolution,
+		step:       c.lineStep(),
+		maxCells:   c.MaxCells,
+		bands:      bands,
+		visits:     make(map[AltitudeCell]struct{}),
+	}
+	err := forEachGeometry(o, func(geom geojson.Object, _ *geojson.Feature) error {
+		return a.collect(geom)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.cells, nil
+}
+
+type altitudeCells struct {
+	resolution int
+	step       float64
+	maxCells   int
+	bands      []float64
+	visits     map[AltitudeCell]struct{}
+	cells      []AltitudeCell
+}
+
+func (a *altitudeCells) collect(o geojson.Object) (err error) {
+	switch typ := o.(type) {
+	case *geojson.Point:
+		center := typ.Center()
+		err = a.add(coordZ{X: center.X, Y: center.Y, Z: typ.Z()})
+	case *geojson.MultiPoint:
+		var coords []coordZ
+		coords, err = parseCoordsZ(gjson.Get(typ.JSON(), "coordinates"), 1)
+		for _, coord := range coords {
+			if err = a.add(coord); err != nil {
+				break
+			}
+		}
+	case *geojson.LineString:
+		var coords []coordZ
+		coords, err = parseCoordsZ(gjson.Get(typ.JSON(), "coordinates"), 1)
+		if err == nil {
+			err = a.addLine(coords)
+		}
+	case *geojson.MultiLineString:
+		gjson.Get(typ.JSON(), "coordinates").ForEach(func(_, line gjson.Result) bool {
+			var coords []coordZ
+			coords, err = parseCoordsZ(line, 1)
+			if err == nil {
+				err = a.addLine(coords)
+			}
+			return err == nil
+		})
+	default:
+		err = fmt.Errorf("expected geojson.Point, geojson.MultiPoint, geojson.LineString or geojson.MultiLineString, got %T", o)
+	}
+	return
+}
+
+func (a *altitudeCells) add(coord coordZ) error {
+	cell := AltitudeCell{
+		Index: h3.FromGeo(h3.GeoCoord{Latitude: coord.Y, Longitude: coord.X}, a.resolution),
+		Band:  sort.Search(len(a.bands), func(i int) bool { return a.bands[i] > coord.Z }),
+	}
+	if _, ok := a.visits[cell]; ok {
+		return nil
+	}
+	a.visits[cell] = struct{}{}
+	a.cells = append(a.cells, cell)
+	if a.maxCells > 0 && len(a.cells) > a.maxCells {
+		return fmt.Errorf("%w. got %d hexagons, expected <= %d hexagons", ErrMaxCells,
+			len(a.cells), a.maxCells)
+	}
+	return nil
+}
+
+// addLine samples the line with sampleSegment like lineToH3,
+// interpolating the altitude by the distance along each segment.
+func (a *altitudeCells) addLine(coords []coordZ) error {
+	if len(coords) < 2 {
+		return fmt.Errorf("got %d points, expected >= 2 points", len(coords))
+	}
+	for i := 1; i < len(coords); i++ {
+		from, to := coords[i-1], coords[i]
+		segment := geometry.Segment{
+			A: geometry.Point{X: from.X, Y: from.Y},
+			B: geometry.Point{X: to.X, Y: to.Y},
+		}
+		dist := distanceMeters(segment)
+		err := sampleSegment(segment, a.step, func(point geometry.Point, distance float64) error {
+			z := to.Z
+			if distance < dist {
+				z = from.Z + (to.Z-from.Z)*distance/dist
+			}
+			return a.add(coordZ{X: point.X, Y: point.Y, Z: z})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// coordZ is a coordinate with the altitude.
+type coordZ struct {
+	X, Y, Z float64
+}
+
+// parseCoordsZ parses a coordinate (depth 0) or an array of coordinates (depth 1).
+func parseCoordsZ(result gjson.Result, depth int) ([]coordZ, error) {
+	if depth == 0 {
+		values := result.Array()
+		if len(values) < 3 {
+			return nil, fmt.Errorf("got coordinate without Z value %s", result.Raw)
+		}
+		return []coordZ{{X: values[0].Float(), Y: values[1].Float(), Z: values[2].Float()}}, nil
+	}
+	coords := make([]coordZ, 0)
+	for _, value := range result.Array() {
+		coord, err := parseCoordsZ(value, depth-1)
+		if err != nil {
+			return nil, err
+		}
+		coords = append(coords, coord...)
+	}
+	return coords, nil
+}
@@ -0,0 +1,126 @@
+package geojson2h3
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestPointToH3Altitude(t *testing.T) {
+	bands := []float64{0, 50, 100}
+	point := geometry.Point{X: -73.95, Y: 40.75}
+	index := h3.FromGeo(h3.GeoCoord{Latitude: point.Y, Longitude: point.X}, 9)
+	for _, tc := range []struct {
+		z    float64
+		band int
+	}{
+		{z: -5, band: 0},
+		{z: 0, band: 1},
+		{z: 49.9, band: 1},
+		{z: 50, band: 2},
+		{z: 120, band: 3},
+	} {
+		cells, err := ToH3Altitude(9, geojson.NewPointZ(point, tc.z), bands)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cells) != 1 {
+			t.Fatalf("have %d, want %d", len(cells), 1)
+		}
+		if have, want := cells[0], (AltitudeCell{Index: index, Band: tc.band}); have != want {
+			t.Fatalf("have %v, want %v", have, want)
+		}
+	}
+}
+
+func TestLineStringToH3Altitude(t *testing.T) {
+	o, err := geojson.Parse(`{"type":"FeatureCollection","features":[
+{"type":"Feature","properties":{},"geometry":{"type":"LineString","coordinates":[[-73.95,40.75,0],[-73.94,40.75,100]]}},
+{"type":"Feature","properties":{},"geometry":{"type":"MultiPoint","coordinates":[[-73.95,40.75,0],[-73.95,40.75,200]]}}
+]}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cells, err := ToH3Altitude(10, o, []float64{25, 50, 75, 150})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the line climbs through the bands 0..3, the last point is in band 4
+	bands := make(map[int]bool)
+	for i, cell := range cells {
+		if i > 0 && cells[i-1].Band > cell.Band {
+			t.Fatalf("have band %d after %d, want ascending bands", cell.Band, cells[i-1].Band)
+		}
+		bands[cell.Band] = true
+	}
+	for band := 0; band <= 4; band++ {
+		if !bands[band] {
+			t.Fatalf("have no band %d, want bands 0..4", band)
+		}
+	}
+	// the 2D conversion covers the same hexagons
+	indexes, err := ToH3(10, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := make(map[h3.H3Index]struct{})
+	for _, cell := range cells {
+		set[cell.Index] = struct{}{}
+	}
+	have := make([]h3.H3Index, 0, len(set))
+	for index := range set {
+		have = append(have, index)
+	}
+	assertSameIndexes(t, indexes, have)
+}
+
+func TestMaxCellsToH3Altitude(t *testing.T) {
+	o, err := geojson.Parse(`{"type":"LineString","coordinates":[[-73.95,40.75,0],[-73.85,40.75,100]]}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv := &Converter{Resolution: 10, MaxCells: 10}
+	if _, err := conv.ToH3Altitude(o, []float64{50}); !errors.Is(err, ErrMaxCells) {
+		t.Fatalf("have %v, want %v", err, ErrMaxCells)
+	}
+	// a point without Z is at the altitude 0
+	point := geojson.NewPoint(geometry.Point{X: -73.95, Y: 40.75})
+	cells, err := conv.ToH3Altitude(point, []float64{-10, 0, 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := cells[0].Band, 2; have != want {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+}
+
+func TestInvalidToH3Altitude(t *testing.T) {
+	point := geojson.NewSimplePoint(geometry.Point{X: -73.95, Y: 40.75})
+	if _, err := ToH3Altitude(9, point, nil); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	line, err := geojson.Parse(`{"type":"LineString","coordinates":[[-73.95,40.75],[-73.85,40.75]]}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ToH3Altitude(9, line, nil); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	pointZ := geojson.NewPointZ(geometry.Point{X: -73.95, Y: 40.75}, 10)
+	if _, err := ToH3Altitude(9, pointZ, []float64{10, 0}); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := ToH3Altitude(16, pointZ, nil); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := ToH3Altitude(9, nil, nil); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	rect := geojson.NewRect(geometry.Rect{Max: geometry.Point{X: 1, Y: 1}})
+	if _, err := ToH3Altitude(9, rect, nil); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
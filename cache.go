@@ -0,0 +1,167 @@
+package geojson2h3
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tidwall/geojson"
+	"github.com/uber/h3-go/v3"
+)
+
+// CacheStats is the usage statistics of a Cache.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	// Len is the number of cached conversions.
+	Len int
+}
+
+// Cache memoizes ToH3 conversions in a LRU cache keyed by a hash of
+// the geometry and the converter options, so repeated conversions of identical
+// shapes are free. The feature properties are not part of the key.
+//
+// It is safe for concurrent use.
+type Cache struct {
+	mu     sync.Mutex
+	size   int
+	items  map[cacheKey]*list.Element
+	lru    *list.List
+	hits   uint64
+	misses uint64
+}
+
+type cacheKey [sha256.Size]byte
+
+type cacheEntry struct {
+	key     cacheKey
+	indexes []h3.H3Index
+}
+
+// NewCache returns a new cache holding at most size conversions.
+func NewCache(size int) (*Cache, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("got invalid cache size %d. expected > 0", size)
+	}
+	return &Cache{
+		size:  size,
+		items: make(map[cacheKey]*list.Element, size),
+		lru:   list.New(),
+	}, nil
+}
+
+// ToH3 converts a GeoJSON objects to a list of hexagons with specified resolution,
+// returning the cached result when the same geometry was converted before.
+// See ToH3 for the list of known objects.
+func (c *Cache) ToH3(resolution int, o geojson.Object) ([]h3.H3Index, error) {
+	return c.Convert(&Converter{Resolution: resolution}, o)
+}
+
+// Convert converts a GeoJSON objects to a list of hexagons with the converter,
+// returning the cached result when the same geometry was converted before
+// with the same converter options. A nil converter is the zero Converter.
+func (c *Cache) Convert(conv *Converter, o geojson.Object) ([]h3.H3Index, error) {
+	if o == nil {
+		return nil, fmt.Errorf("geojson.Object is nil")
+	}
+	if conv == nil {
+		conv = &Converter{}
+	}
+	key := geometryKey(o, conv)
+
+	c.mu.Lock()
+	if elem, ok := c.items[key]; ok {
+		c.lru.MoveToFront(elem)
+		c.hits++
+		indexes := elem.Value.(*cacheEntry).indexes
+		c.mu.Unlock()
+		return copyIndexes(indexes), nil
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	indexes, err := conv.ToH3(o)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		c.lru.MoveToFront(elem)
+		return copyIndexes(indexes), nil
+	}
+	c.items[key] = c.lru.PushFront(&cacheEntry{key: key, indexes: indexes})
+	for c.lru.Len() > c.size {
+		last := c.lru.Back()
+		c.lru.Remove(last)
+		delete(c.items, last.Value.(*cacheEntry).key)
+	}
+	return copyIndexes(indexes), nil
+}
+
+// Stats returns the usage statistics of the cache.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{
+		Hits:   c.hits,
+		Misses: c.misses,
+		Len:    c.lru.Len(),
+	}
+}
+
+// Reset removes all cached conversions and resets the statistics.
+func (c *Cache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[cacheKey]*list.Element, c.size)
+	c.lru.Init()
+	c.hits, c.misses = 0, 0
+}
+
+// geometryKey returns a hash of the geometry of the object and the converter options.
+// The features are hashed by their geometries only.
+func geometryKey(o geojson.Object, conv *Converter) cacheKey {
+	h := sha256.New()
+	// the options are hashed one by one, so a new field must be added here
+	_, _ = fmt.Fprintf(h, "%d|%d|%t|%d|%v|%d|%t|%d|", conv.Resolution, int(conv.Containment),
+		conv.Compact, conv.MaxCells, conv.LineStep, int(conv.Fallback), conv.Perimeter, int(conv.FillRule))
+	writeGeometry(h, o)
+	var key cacheKey
+	h.Sum(key[:0])
+	return key
+}
+
+func writeGeometry(w io.Writer, o geojson.Object) {
+	// ToH3 converts the geometries one by one, so the top-level type
+	// and the geometries with their feature markers identify the result.
+	_, _ = fmt.Fprintf(w, "%T", o)
+	err := forEachGeometry(o, func(geom geojson.Object, feature *geojson.Feature) error {
+		if feature != nil {
+			_, _ = w.Write([]byte("feature:"))
+		}
+		_, _ = w.Write(geom.AppendJSON(nil))
+		// The extended objects share the JSON representation with
+		// the standard ones, but are converted differently.
+		_, _ = fmt.Fprintf(w, "%T", geom)
+		if circle, ok := geom.(*geojson.Circle); ok {
+			// The JSON of a circle has no steps, so the converted
+			// polygon is hashed as well.
+			_, _ = w.Write(circle.Primative().AppendJSON(nil))
+		}
+		_, _ = w.Write([]byte(","))
+		return nil
+	})
+	if err != nil {
+		_, _ = w.Write([]byte(err.Error()))
+	}
+}
+
+func copyIndexes(indexes []h3.H3Index) []h3.H3Index {
+	result := make([]h3.H3Index, len(indexes))
+	copy(result, indexes)
+	return result
+}
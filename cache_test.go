@@ -0,0 +1,162 @@
+package geojson2h3
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestCacheToH3(t *testing.T) {
+	cache, err := NewCache(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := strToPoints(`
+[-74.0, 40.7],
+[-73.9, 40.7],
+[-73.9, 40.8],
+[-74.0, 40.7]
+`)
+	polygon := geojson.NewPolygon(geometry.NewPoly(points, nil, nil))
+	want, err := ToH3(8, polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		have, err := cache.ToH3(8, polygon)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSameIndexes(t, want, have)
+		have[0] = 0
+	}
+	// same geometry with different properties
+	if _, err := cache.ToH3(8, geojson.NewFeature(polygon, `{"properties":{"a":1}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.ToH3(8, geojson.NewFeature(polygon, `{"properties":{"a":2}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := (CacheStats{Hits: 3, Misses: 2, Len: 2}), cache.Stats(); want != have {
+		t.Fatalf("have %+v, want %+v", have, want)
+	}
+
+	// evicts the least recently used polygon
+	if _, err := cache.ToH3(9, polygon); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.ToH3(8, polygon); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := (CacheStats{Hits: 3, Misses: 4, Len: 2}), cache.Stats(); want != have {
+		t.Fatalf("have %+v, want %+v", have, want)
+	}
+
+	cache.Reset()
+	if want, have := (CacheStats{}), cache.Stats(); want != have {
+		t.Fatalf("have %+v, want %+v", have, want)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	point := geometry.Point{X: -74.143609, Y: 40.751389}
+	keys := []cacheKey{
+		geometryKey(geojson.NewPoint(point), &Converter{Resolution: 7}),
+		geometryKey(geojson.NewPoint(point), &Converter{Resolution: 8}),
+		geometryKey(geojson.NewSimplePoint(geometry.Point{X: -74.143609, Y: 40.751388}), &Converter{Resolution: 7}),
+		geometryKey(geojson.NewRect(geometry.Rect{Min: point, Max: point}), &Converter{Resolution: 7}),
+		geometryKey(geojson.NewFeatureCollection([]geojson.Object{
+			geojson.NewFeature(geojson.NewPoint(point), ""),
+		}), &Converter{Resolution: 7}),
+		geometryKey(geojson.NewPoint(point), &Converter{Resolution: 7, Compact: true}),
+		geometryKey(geojson.NewPoint(point), &Converter{Resolution: 7, Containment: ContainmentFull}),
+		geometryKey(geojson.NewPoint(point), &Converter{Resolution: 7, MaxCells: 10}),
+		geometryKey(geojson.NewPoint(point), &Converter{Resolution: 7, LineStep: 500}),
+		geometryKey(geojson.NewPoint(point), &Converter{Resolution: 7, Fallback: FallbackEmpty}),
+		geometryKey(geojson.NewPoint(point), &Converter{Resolution: 7, Perimeter: true}),
+		geometryKey(geojson.NewPoint(point), &Converter{Resolution: 7, FillRule: FillRuleEvenOdd}),
+		geometryKey(geojson.NewCircle(point, 5000, 4), &Converter{Resolution: 7}),
+		geometryKey(geojson.NewCircle(point, 5000, 64), &Converter{Resolution: 7}),
+	}
+	seen := make(map[cacheKey]int)
+	for i, key := range keys {
+		if j, ok := seen[key]; ok {
+			t.Fatalf("keys %d and %d are equal", j, i)
+		}
+		seen[key] = i
+	}
+}
+
+func TestCacheCircleSteps(t *testing.T) {
+	cache, err := NewCache(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	center := geometry.Point{X: -74.143609, Y: 40.751389}
+	for _, steps := range []int{4, 64} {
+		circle := geojson.NewCircle(center, 5000, steps)
+		want, err := ToH3(9, circle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		have, err := cache.ToH3(9, circle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSameIndexes(t, want, have)
+	}
+}
+
+func TestConcurrentCacheToH3(t *testing.T) {
+	cache, err := NewCache(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	point := geojson.NewPoint(geometry.Point{X: -74.143609, Y: 40.751389})
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(res int) {
+			defer wg.Done()
+			indexes, err := cache.ToH3(res, point)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if len(indexes) != 1 || h3.Resolution(indexes[0]) != res {
+				t.Errorf("unexpected indexes %v", indexes)
+			}
+		}(i % 4)
+	}
+	wg.Wait()
+	if have := cache.Stats(); have.Hits+have.Misses != 16 || have.Len != 4 {
+		t.Fatalf("unexpected stats %+v", have)
+	}
+}
+
+func TestInvalidCache(t *testing.T) {
+	if _, err := NewCache(0); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	cache, err := NewCache(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.ToH3(7, nil); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	point := geojson.NewPoint(geometry.Point{X: -74.143609, Y: 40.751389})
+	if _, err := cache.ToH3(16, point); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	if want, have := 0, cache.Stats().Len; want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	// a nil converter is the zero converter
+	if _, err := cache.Convert(nil, point); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,56 @@
+package geojson2h3
+
+import (
+	"sort"
+
+	"github.com/tidwall/geojson"
+	"github.com/uber/h3-go/v3"
+)
+
+// Component is a group of hexagons connected by their edges.
+type Component struct {
+	// Indexes of the hexagons, sorted.
+	Indexes []h3.H3Index
+	// Outline of the hexagons, nil unless requested.
+	Outline *geojson.MultiPolygon
+}
+
+// ToComponents splits a set of hexagons with the same resolution into the groups
+// of hexagons connected by their edges, the largest groups first.
+// If outline is true, each group is returned with its outline.
+func ToComponents(indexes []h3.H3Index, outline bool) ([]Component, error) {
+	set, err := cellSet(indexes)
+	if err != nil {
+		return nil, err
+	}
+	visits := make(map[h3.H3Index]struct{}, len(set))
+	components := make([]Component, 0)
+	for _, start := range setToCells(set) {
+		if _, ok := visits[start]; ok {
+			continue
+		}
+		visits[start] = struct{}{}
+		group := []h3.H3Index{start}
+		for i := 0; i < len(group); i++ {
+			for _, neighbour := range neighbours(group[i]) {
+				if _, ok := set[neighbour]; !ok {
+					continue
+				}
+				if _, ok := visits[neighbour]; ok {
+					continue
+				}
+				visits[neighbour] = struct{}{}
+				group = append(group, neighbour)
+			}
+		}
+		component := Component{Indexes: sortCells(group)}
+		if outline {
+			component.Outline = geojson.NewMultiPolygon(toPolygons(group))
+		}
+		components = append(components, component)
+	}
+	sort.SliceStable(components, func(i, j int) bool {
+		return len(components[i].Indexes) > len(components[j].Indexes)
+	})
+	return components, nil
+}
@@ -0,0 +1,65 @@
+package geojson2h3
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestToComponents(t *testing.T) {
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 9)
+	disk := h3.KRing(origin, 1)
+	ring, err := h3.HexRing(origin, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	far := h3.KRing(origin, 10)
+	isolated := far[len(far)-1]
+	indexes := append(append(append([]h3.H3Index{}, disk...), ring...), isolated)
+
+	components, err := ToComponents(indexes, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(components) != 3 {
+		t.Fatalf("have %d, want %d", len(components), 3)
+	}
+	assertSameIndexes(t, ring, components[0].Indexes)
+	assertSameIndexes(t, disk, components[1].Indexes)
+	assertSameIndexes(t, []h3.H3Index{isolated}, components[2].Indexes)
+	// the ring encloses a hole
+	polygons := components[0].Outline.Base()
+	if len(polygons) != 1 || len(polygons[0].(*geojson.Polygon).Base().Holes) != 1 {
+		t.Fatalf("have %d polygons, want 1 polygon with 1 hole", len(polygons))
+	}
+	for _, component := range components[1:] {
+		if n := len(component.Outline.Base()); n != 1 {
+			t.Fatalf("have %d, want %d", n, 1)
+		}
+	}
+
+	components, err = ToComponents(indexes, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, component := range components {
+		if component.Outline != nil {
+			t.Fatalf("have outline, want nil")
+		}
+	}
+}
+
+func TestInvalidToComponents(t *testing.T) {
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 9)
+	if _, err := ToComponents([]h3.H3Index{origin, h3.ToParent(origin, 8)}, false); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	components, err := ToComponents(nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(components) != 0 {
+		t.Fatalf("have %d, want %d", len(components), 0)
+	}
+}
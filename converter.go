@@ -0,0 +1,392 @@
+package geojson2h3
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+// Containment defines which hexagons of a polygon are included in the result.
+type Containment int
+
+const (
+	// ContainmentCenter includes the hexagons whose centers are within the polygon.
+	ContainmentCenter Containment = iota
+	// ContainmentIntersects includes the hexagons which intersect the polygon.
+	ContainmentIntersects
+	// ContainmentFull includes the hexagons which are fully within the polygon.
+	ContainmentFull
+)
+
+func (c Containment) String() string {
+	switch c {
+	case ContainmentCenter:
+		return "center"
+	case ContainmentIntersects:
+		return "intersects"
+	case ContainmentFull:
+		return "full"
+	default:
+		return fmt.Sprintf("Containment(%d)", int(c))
+	}
+}
+
+// Fallback defines the result for the polygons, Rect and Circle
+// without hexagons selected by the containment, e.g. the shapes
+// smaller than a hexagon.
+type Fallback int
+
+const (
+	// FallbackCenter returns the hexagon of the shape center.
+	FallbackCenter Fallback = iota
+	// FallbackIntersects returns the hexagons which intersect the shape.
+	FallbackIntersects
+	// FallbackEmpty returns no hexagons.
+	FallbackEmpty
+	// FallbackError returns an error.
+	FallbackError
+)
+
+func (f Fallback) String() string {
+	switch f {
+	case FallbackCenter:
+		return "center"
+	case FallbackIntersects:
+		return "intersects"
+	case FallbackEmpty:
+		return "empty"
+	case FallbackError:
+		return "error"
+	default:
+		return fmt.Sprintf("Fallback(%d)", int(f))
+	}
+}
+
+// FillRule defines how the parts of a MultiPolygon are combined.
+type FillRule int
+
+const (
+	// FillRuleUnion converts each part independently and merges the hexagons.
+	FillRuleUnion FillRule = iota
+	// FillRuleEvenOdd includes the areas within an odd number of rings
+	// of all parts, so the nested parts and the overlaps of the parts alternate
+	// between filled and empty, e.g. an island inside a lake inside a land.
+	FillRuleEvenOdd
+)
+
+func (r FillRule) String() string {
+	switch r {
+	case FillRuleUnion:
+		return "union"
+	case FillRuleEvenOdd:
+		return "even-odd"
+	default:
+		return fmt.Sprintf("FillRule(%d)", int(r))
+	}
+}
+
+// ErrMaxCells is returned when a conversion exceeds Converter.MaxCells
+// or a decoding exceeds the limit of UnmarshalCells.
+var ErrMaxCells = errors.New("too many hexagons")
+
+// fullCoverage is the smallest coverage fraction of a hexagon
+// considered to be fully within a polygon.
+const fullCoverage = 1 - 1e-6
+
+// Converter converts GeoJSON objects to hexagons.
+// The zero value converts with resolution 0 and the same rules as ToH3.
+type Converter struct {
+	// Resolution of the hexagons, from 0 to 15.
+	Resolution int
+	// Containment of the polygons, Rect and Circle.
+	Containment Containment
+	// Compact compacts the result with h3.Compact.
+	Compact bool
+	// MaxCells limits the number of hexagons before compaction, zero means no limit.
+	// The polygons, Rect and Circle are checked by an estimate before the filling,
+	// the hexagons of the points and line samples are counted one by one,
+	// so the conversion stops early.
+	MaxCells int
+	// LineStep is the sampling distance of the lines in meters,
+	// zero means the default distance of the resolution. It must be
+	// at least a tenth of the hexagon edge length.
+	LineStep float64
+	// Fallback of the polygons, Rect and Circle without hexagons.
+	Fallback Fallback
+	// Perimeter keeps only the perimeter hexagons of each polygon, Rect and Circle,
+	// see ToPerimeter. The outer and inner hexagons are merged,
+	// use ToH3Perimeter to get them apart.
+	Perimeter bool
+	// FillRule of the MultiPolygon parts.
+	FillRule FillRule
+}
+
+// ToH3 converts a GeoJSON objects to a list of hexagons.
+// See ToH3 for the list of known objects.
+func (c *Converter) ToH3(o geojson.Object) ([]h3.H3Index, error) {
+	if o == nil {
+		return nil, fmt.Errorf("geojson.Object is nil")
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	indexes, _, err := c.convert(o)
+	if err != nil {
+		return nil, err
+	}
+	if c.MaxCells > 0 && len(indexes) > c.MaxCells {
+		return nil, fmt.Errorf("%w. got %d hexagons, expected <= %d hexagons", ErrMaxCells,
+			len(indexes), c.MaxCells)
+	}
+	if c.Compact && len(indexes) > 0 {
+		indexes = h3.Compact(indexes)
+	}
+	return indexes, nil
+}
+
+func (c *Converter) validate() error {
+	if c.Resolution < 0 || c.Resolution > 15 {
+		return fmt.Errorf("got invalid resolution %d. expected from 0 to 15",
+			c.Resolution)
+	}
+	switch c.Containment {
+	case ContainmentCenter, ContainmentIntersects, ContainmentFull:
+	default:
+		return fmt.Errorf("got invalid containment %s", c.Containment)
+	}
+	if c.MaxCells < 0 {
+		return fmt.Errorf("got invalid max cells %d. expected >= 0", c.MaxCells)
+	}
+	if c.LineStep < 0 || math.IsNaN(c.LineStep) || math.IsInf(c.LineStep, 0) {
+		return fmt.Errorf("got invalid line step %v. expected >= 0", c.LineStep)
+	}
+	if minStep := minLineStep(c.Resolution); c.LineStep > 0 && c.LineStep < minStep {
+		return fmt.Errorf("got invalid line step %v. expected >= %v at resolution %d",
+			c.LineStep, minStep, c.Resolution)
+	}
+	switch c.Fallback {
+	case FallbackCenter, FallbackIntersects, FallbackEmpty, FallbackError:
+	default:
+		return fmt.Errorf("got invalid fallback %s", c.Fallback)
+	}
+	switch c.FillRule {
+	case FillRuleUnion, FillRuleEvenOdd:
+	default:
+		return fmt.Errorf("got invalid fill rule %s", c.FillRule)
+	}
+	return nil
+}
+
+// minLineStepDivisor divides the hexagon edge to the smallest line step,
+// the finer steps only add samples within the same hexagons.
+const minLineStepDivisor = 10
+
+func minLineStep(resolution int) float64 {
+	return h3.EdgeLengthM(resolution) / minLineStepDivisor
+}
+
+func (c *Converter) lineStep() float64 {
+	if c.LineStep > 0 {
+		return c.LineStep
+	}
+	return stepForResolution(c.Resolution)
+}
+
+func (c *Converter) rectToH3(rect *geojson.Rect) ([]h3.H3Index, Perimeter, error) {
+	polygon := rectToPolygon(rect)
+	return c.fillPolygon(polygon, toGeoPolygon(polygon), rect.Center())
+}
+
+func (c *Converter) circleToH3(circle *geojson.Circle) ([]h3.H3Index, Perimeter, error) {
+	polygon, err := circleToPolygon(circle)
+	if err != nil {
+		return nil, Perimeter{}, err
+	}
+	return c.fillPolygon(polygon, toGeoPolygon(polygon), circle.Center())
+}
+
+func (c *Converter) polygonToH3(polygon *geojson.Polygon) ([]h3.H3Index, Perimeter, error) {
+	return c.fillPolygon(polygon, toGeoPolygon(polygon), polygon.Center())
+}
+
+// fillPolygon selects the hexagons of the polygon like fill.
+// The geoPolygon is the same polygon as lat/lng coordinates.
+func (c *Converter) fillPolygon(polygon *geojson.Polygon, geoPolygon h3.GeoPolygon,
+	center geometry.Point) ([]h3.H3Index, Perimeter, error) {
+	if err := c.checkEstimate(polygon); err != nil {
+		return nil, Perimeter{}, err
+	}
+	return c.fill(center, func(containment Containment) ([]h3.H3Index, error) {
+		if containment == ContainmentCenter {
+			return h3.Polyfill(geoPolygon, c.Resolution), nil
+		}
+		return c.coverageToH3(polygon, containment)
+	})
+}
+
+// fill selects the hexagons of a shape by the containment, applying
+// the fallback when no hexagon is selected. If Perimeter is enabled,
+// only the perimeter hexagons of the selected or fallback intersecting
+// hexagons are kept and returned apart as well.
+func (c *Converter) fill(center geometry.Point,
+	selectFn func(containment Containment) ([]h3.H3Index, error)) ([]h3.H3Index, Perimeter, error) {
+	indexes, err := selectFn(c.Containment)
+	if err != nil {
+		return nil, Perimeter{}, err
+	}
+	if len(indexes) == 0 {
+		switch c.Fallback {
+		case FallbackIntersects:
+			indexes, err = selectFn(ContainmentIntersects)
+			if err != nil {
+				return nil, Perimeter{}, err
+			}
+		case FallbackEmpty:
+			return []h3.H3Index{}, Perimeter{}, nil
+		case FallbackError:
+			return nil, Perimeter{}, fmt.Errorf("got polygon without hexagons at resolution %d, centered at %v",
+				c.Resolution, center)
+		default:
+			return pointToH3(c.Resolution, geojson.NewPoint(center)), Perimeter{}, nil
+		}
+	}
+	if !c.Perimeter || len(indexes) == 0 {
+		return indexes, Perimeter{}, nil
+	}
+	set := make(map[h3.H3Index]struct{}, len(indexes))
+	for _, index := range indexes {
+		set[index] = struct{}{}
+	}
+	perimeter := toPerimeter(set)
+	return perimeter.Indexes(), perimeter, nil
+}
+
+// coverageToH3 selects the hexagons by the fraction of their area covered by the polygon.
+func (c *Converter) coverageToH3(polygon *geojson.Polygon, containment Containment) ([]h3.H3Index, error) {
+	coverage := make(map[h3.H3Index]float64)
+	if err := polygonToH3Coverage(c.Resolution, polygon, coverage); err != nil {
+		return nil, err
+	}
+	indexes := make([]h3.H3Index, 0, len(coverage))
+	for index, fraction := range coverage {
+		if containment == ContainmentFull && fraction < fullCoverage {
+			continue
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes, nil
+}
+
+const (
+	// maxEstimateFactor is how many times the estimated hexagons of a polygon
+	// may exceed MaxCells, since the estimate by the average hexagon area
+	// is not exact. The exact number is checked after the filling.
+	maxEstimateFactor = 2
+	// maxBoundsFactor is how many times the hexagons of the polygon bounding box
+	// may exceed MaxCells. The filling allocates memory by the bounding box,
+	// so the thin diagonal polygons are limited as well.
+	maxBoundsFactor = 16
+)
+
+// checkEstimate returns an error if the polygon is estimated to have far more
+// than MaxCells hexagons, before any hexagons are generated.
+func (c *Converter) checkEstimate(polygon *geojson.Polygon) error {
+	if c.MaxCells == 0 {
+		return nil
+	}
+	cellArea := h3.HexAreaM2(c.Resolution)
+	if estimate := polygonArea(polygon) / cellArea; estimate > float64(maxEstimateFactor*c.MaxCells) {
+		return fmt.Errorf("%w. got about %.0f hexagons, expected <= %d hexagons", ErrMaxCells,
+			estimate, c.MaxCells)
+	}
+	if estimate := rectArea(polygon.Rect()) / cellArea; estimate > float64(maxBoundsFactor*c.MaxCells) {
+		return fmt.Errorf("%w. got bounds of about %.0f hexagons, expected <= %d hexagons", ErrMaxCells,
+			estimate, maxBoundsFactor*c.MaxCells)
+	}
+	return nil
+}
+
+// rectArea returns the area of the rect in square meters,
+// measured at the latitude closest to the equator.
+func rectArea(rect geometry.Rect) float64 {
+	lat := 0.0
+	if rect.Min.Y > 0 {
+		lat = rect.Min.Y
+	} else if rect.Max.Y < 0 {
+		lat = rect.Max.Y
+	}
+	width := (rect.Max.X - rect.Min.X) * math.Pi / 180 * math.Cos(lat*math.Pi/180)
+	height := (rect.Max.Y - rect.Min.Y) * math.Pi / 180
+	return width * height * earthRadiusMeters * earthRadiusMeters
+}
+
+// cellAccumulator merges the hexagons without duplicates,
+// counting them against MaxCells.
+type cellAccumulator struct {
+	maxCells int
+	visits   map[h3.H3Index]struct{}
+	indexes  []h3.H3Index
+}
+
+func (c *Converter) newAccumulator() *cellAccumulator {
+	return &cellAccumulator{
+		maxCells: c.MaxCells,
+		visits:   make(map[h3.H3Index]struct{}),
+		indexes:  make([]h3.H3Index, 0),
+	}
+}
+
+func (a *cellAccumulator) add(indexes []h3.H3Index) error {
+	for _, index := range indexes {
+		if err := a.addIndex(index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *cellAccumulator) addIndex(index h3.H3Index) error {
+	if _, ok := a.visits[index]; ok {
+		return nil
+	}
+	a.visits[index] = struct{}{}
+	a.indexes = append(a.indexes, index)
+	if a.maxCells > 0 && len(a.indexes) > a.maxCells {
+		return fmt.Errorf("%w. got %d hexagons, expected <= %d hexagons", ErrMaxCells,
+			len(a.indexes), a.maxCells)
+	}
+	return nil
+}
+
+// rectToPolygon returns the outline of the rect as a polygon.
+// H3 fills the polygons in lat/lng space, so the edges of the rect
+// follow the parallels and meridians without densification.
+func rectToPolygon(rect *geojson.Rect) *geojson.Polygon {
+	bounds := rect.Base()
+	minX, maxX := math.Min(bounds.Min.X, bounds.Max.X), math.Max(bounds.Min.X, bounds.Max.X)
+	minY, maxY := math.Min(bounds.Min.Y, bounds.Max.Y), math.Max(bounds.Min.Y, bounds.Max.Y)
+	points := []geometry.Point{
+		{X: minX, Y: minY},
+		{X: maxX, Y: minY},
+		{X: maxX, Y: maxY},
+		{X: minX, Y: maxY},
+		{X: minX, Y: minY},
+	}
+	return geojson.NewPolygon(geometry.NewPoly(points, nil, &geometry.IndexOptions{
+		Kind: geometry.None,
+	}))
+}
+
+func circleToPolygon(circle *geojson.Circle) (*geojson.Polygon, error) {
+	polygon, ok := circle.Primative().(*geojson.Polygon)
+	if !ok {
+		return nil, fmt.Errorf("expected geojson.Polygon, got %T", circle.Primative())
+	}
+	return polygon, nil
+}
@@ -0,0 +1,273 @@
+package geojson2h3
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestConverterToH3(t *testing.T) {
+	points := strToPoints(`
+[-74.0, 40.7],
+[-73.9, 40.7],
+[-73.9, 40.8],
+[-74.0, 40.7]
+`)
+	polygon := geojson.NewPolygon(geometry.NewPoly(points, nil, nil))
+	want, err := ToH3(8, polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	center, err := (&Converter{Resolution: 8}).ToH3(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, want, center)
+
+	intersects, err := (&Converter{Resolution: 8, Containment: ContainmentIntersects}).ToH3(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	full, err := (&Converter{Resolution: 8, Containment: ContainmentFull}).ToH3(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(len(full) < len(center) && len(center) < len(intersects)) {
+		t.Fatalf("have %d < %d < %d, want full < center < intersects",
+			len(full), len(center), len(intersects))
+	}
+	contains := make(map[h3.H3Index]bool, len(intersects))
+	for _, index := range intersects {
+		contains[index] = true
+	}
+	for _, index := range center {
+		if !contains[index] {
+			t.Fatalf("have %s not in intersects, want center within intersects", h3.ToString(index))
+		}
+	}
+	contains = make(map[h3.H3Index]bool, len(center))
+	for _, index := range center {
+		contains[index] = true
+	}
+	for _, index := range full {
+		if !contains[index] {
+			t.Fatalf("have %s not in center, want full within center", h3.ToString(index))
+		}
+	}
+}
+
+func TestCompactConverterToH3(t *testing.T) {
+	points := strToPoints(`
+[-74.0, 40.7],
+[-73.9, 40.7],
+[-73.9, 40.8],
+[-74.0, 40.7]
+`)
+	polygon := geojson.NewPolygon(geometry.NewPoly(points, nil, nil))
+	want, err := ToH3(9, polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := (&Converter{Resolution: 9, Compact: true}).ToH3(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) >= len(want) {
+		t.Fatalf("have %d, want < %d", len(have), len(want))
+	}
+	uncompacted, err := h3.Uncompact(have, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, want, uncompacted)
+}
+
+func TestMaxCellsConverterToH3(t *testing.T) {
+	rect := geojson.NewRect(geometry.Rect{
+		Min: geometry.Point{X: -74.0, Y: 40.7},
+		Max: geometry.Point{X: -73.9, Y: 40.8},
+	})
+	indexes, err := ToH3(7, rect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv := &Converter{Resolution: 7, MaxCells: len(indexes)}
+	if _, err := conv.ToH3(rect); err != nil {
+		t.Fatal(err)
+	}
+	conv.MaxCells--
+	if _, err := conv.ToH3(rect); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
+
+func TestMaxCellsEstimateConverterToH3(t *testing.T) {
+	conv := &Converter{Resolution: 15, MaxCells: 1000}
+	// would allocate billions of hexagons without the estimate
+	continent := geojson.NewRect(geometry.Rect{
+		Min: geometry.Point{X: -120, Y: 30},
+		Max: geometry.Point{X: -80, Y: 50},
+	})
+	if _, err := conv.ToH3(continent); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	sliver := geojson.NewPolygon(geometry.NewPoly(strToPoints(`
+[-120.0, 30.0],
+[-80.0, 50.0],
+[-80.0, 50.00001],
+[-120.0, 30.0]
+`), nil, nil))
+	if _, err := conv.ToH3(sliver); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	conv = &Converter{Resolution: 9, MaxCells: 3}
+	points := geojson.NewMultiPoint([]geometry.Point{
+		{X: -74.0, Y: 40.7},
+		{X: -74.0, Y: 40.7},
+		{X: -73.9, Y: 40.7},
+		{X: -73.8, Y: 40.7},
+	})
+	if _, err := conv.ToH3(points); err != nil {
+		t.Fatal(err)
+	}
+	collection, err := geojson.Parse(`{"type":"GeometryCollection","geometries":[
+{"type":"Point","coordinates":[-74.0,40.7]},
+{"type":"Point","coordinates":[-73.9,40.7]},
+{"type":"Point","coordinates":[-73.8,40.7]},
+{"type":"Point","coordinates":[-73.7,40.7]}
+]}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conv.ToH3(collection); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
+
+func TestMaxCellsLineConverterToH3(t *testing.T) {
+	// about 20 million samples at resolution 15 without the limit
+	line := geojson.NewLineString(geometry.NewLine(strToPoints(`
+[-90.0, 0.0],
+[0.0, 0.1],
+[89.0, 0.0]
+`), nil))
+	conv := &Converter{Resolution: 15, MaxCells: 1000}
+	_, err := conv.ToH3(line)
+	if !errors.Is(err, ErrMaxCells) {
+		t.Fatalf("have %v, want %v", err, ErrMaxCells)
+	}
+	multi := geojson.NewMultiLineString([]*geometry.Line{line.Base()})
+	_, err = conv.ToH3(multi)
+	if !errors.Is(err, ErrMaxCells) {
+		t.Fatalf("have %v, want %v", err, ErrMaxCells)
+	}
+}
+
+func TestInvalidConverter(t *testing.T) {
+	point := geojson.NewPoint(geometry.Point{X: -74.0, Y: 40.7})
+	converters := []Converter{
+		{Resolution: -1},
+		{Resolution: 16},
+		{Resolution: 7, Containment: Containment(10)},
+		{Resolution: 7, MaxCells: -1},
+		{Resolution: 7, LineStep: -1},
+		{Resolution: 7, LineStep: 1e-6},
+		{Resolution: 7, Fallback: Fallback(10)},
+	}
+	for _, conv := range converters {
+		if _, err := conv.ToH3(point); err == nil {
+			t.Fatalf("have nil, want error for %+v", conv)
+		}
+	}
+	if _, err := (&Converter{}).ToH3(nil); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
+
+func TestFallbackConverterToH3(t *testing.T) {
+	points := strToPoints(`
+[-74.0, 40.7],
+[-73.999, 40.7],
+[-73.999, 40.701],
+[-74.0, 40.7]
+`)
+	polygon := geojson.NewPolygon(geometry.NewPoly(points, nil, nil))
+	center := h3.FromGeo(h3.GeoCoord{Latitude: polygon.Center().Y, Longitude: polygon.Center().X}, 5)
+
+	have, err := (&Converter{Resolution: 5}).ToH3(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) != 1 || have[0] != center {
+		t.Fatalf("have %v, want [%v]", have, center)
+	}
+	have, err = (&Converter{Resolution: 5, Fallback: FallbackIntersects}).ToH3(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) == 0 {
+		t.Fatalf("have 0, want > 0")
+	}
+	have, err = (&Converter{Resolution: 5, Fallback: FallbackEmpty}).ToH3(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) != 0 {
+		t.Fatalf("have %d, want 0", len(have))
+	}
+	if _, err := (&Converter{Resolution: 5, Fallback: FallbackError}).ToH3(polygon); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	// the fallback applies to each polygon of a MultiPolygon
+	multi := geojson.NewMultiPolygon([]*geometry.Poly{polygon.Base()})
+	if _, err := (&Converter{Resolution: 5, Fallback: FallbackError}).ToH3(multi); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	// the fallback does not apply when the polygon has hexagons
+	have, err = (&Converter{Resolution: 15, Fallback: FallbackError}).ToH3(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) == 0 {
+		t.Fatalf("have 0, want > 0")
+	}
+}
+
+func TestLineStepConverterToH3(t *testing.T) {
+	line := geojson.NewLineString(geometry.NewLine(strToPoints(`
+[-74.0, 40.7],
+[-73.9, 40.8]
+`), nil))
+	coarse, err := (&Converter{Resolution: 10, LineStep: 5000}).ToH3(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fine, err := (&Converter{Resolution: 10, LineStep: 10}).ToH3(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coarse) >= len(fine) {
+		t.Fatalf("have %d, want < %d", len(coarse), len(fine))
+	}
+	want, err := ToH3(10, line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := (&Converter{Resolution: 10}).ToH3(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, want, have)
+
+	// a tiny step would sample the line millions of times
+	if _, err := (&Converter{Resolution: 10, LineStep: 1e-6}).ToH3(line); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	minStep := (&Converter{Resolution: 10, LineStep: h3.EdgeLengthM(10) / 10})
+	if _, err := minStep.ToH3(line); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,258 @@
+package geojson2h3
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/uber/h3-go/v3"
+)
+
+// The binary format of a set of hexagons:
+//
+//	magic      "H3CS"
+//	version    1 byte
+//	flags      1 byte, flagCompacted if the hexagons are compacted
+//	resolution 1 byte, the resolution of the hexagons before compaction
+//	count      uvarint, the number of stored hexagons
+//	indexes    uvarint each, the first index followed by the deltas
+//	           between the sorted indexes
+const (
+	cellsMagic   = "H3CS"
+	cellsVersion = 1
+
+	flagCompacted = 1 << 0
+)
+
+// MarshalCells encodes a set of hexagons to the compact binary format.
+// The hexagons are sorted and deduplicated, the deltas between them are varint encoded.
+// If compact is true, the hexagons are compacted with h3.Compact before encoding,
+// in that case all hexagons must have the same resolution.
+func MarshalCells(indexes []h3.H3Index, compact bool) ([]byte, error) {
+	resolution := 0
+	for i, index := range indexes {
+		if !h3.IsValid(index) {
+			return nil, fmt.Errorf("got invalid h3 index %x", uint64(index))
+		}
+		if i == 0 {
+			resolution = h3.Resolution(index)
+		} else if compact && h3.Resolution(index) != resolution {
+			return nil, fmt.Errorf("got mixed resolutions %d and %d, expected the same resolution to compact",
+				resolution, h3.Resolution(index))
+		}
+	}
+	cells := sortCells(indexes)
+	flags := byte(0)
+	if compact && len(cells) > 0 {
+		cells = sortCells(h3.Compact(cells))
+		flags |= flagCompacted
+	}
+	buf := make([]byte, 0, len(cellsMagic)+3+binary.MaxVarintLen64*(len(cells)+1))
+	buf = append(buf, cellsMagic...)
+	buf = append(buf, cellsVersion, flags, byte(resolution))
+	buf = appendUvarint(buf, uint64(len(cells)))
+	prev := uint64(0)
+	for _, index := range cells {
+		buf = appendUvarint(buf, uint64(index)-prev)
+		prev = uint64(index)
+	}
+	return buf, nil
+}
+
+// UnmarshalCells decodes a set of hexagons encoded by MarshalCells.
+// The compacted hexagons are uncompacted to the original resolution,
+// the result is sorted. maxCells limits the number of decoded hexagons,
+// zero means no limit; a single compacted hexagon may expand to billions
+// of hexagons, so the untrusted data must be limited. The limit is checked
+// before uncompacting each hexagon, counting the compacted pentagons
+// as hexagons.
+func UnmarshalCells(data []byte, maxCells int) ([]h3.H3Index, error) {
+	if maxCells < 0 {
+		return nil, fmt.Errorf("got invalid max cells %d. expected >= 0", maxCells)
+	}
+	r, err := NewCellReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	r.maxCells = uint64(maxCells)
+	// every hexagon takes at least one byte, so the count is bounded
+	// by the payload and cannot be used to allocate arbitrary memory
+	header := len(cellsMagic) + 3
+	_, n := binary.Uvarint(data[header:])
+	if payload := len(data) - header - n; uint64(payload) < r.count {
+		return nil, fmt.Errorf("cells invalid format. got %d hexagons in %d bytes", r.count, payload)
+	}
+	indexes := make([]h3.H3Index, 0, r.Len())
+	for {
+		index, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+	if r.compacted {
+		sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	}
+	return indexes, nil
+}
+
+// CellReader reads a set of hexagons encoded by MarshalCells one by one,
+// without loading the whole set into memory.
+type CellReader struct {
+	r          *bufio.Reader
+	compacted  bool
+	resolution int
+	count      uint64
+	read       uint64
+	prev       uint64
+	// parent and child iterate the children of a compacted hexagon,
+	// child is the next child to return or zero
+	parent h3.H3Index
+	child  h3.H3Index
+	// maxCells limits the number of hexagons after uncompaction, zero means no limit,
+	// decoded counts them with all children of each stored hexagon
+	maxCells uint64
+	decoded  uint64
+}
+
+// NewCellReader returns a new CellReader reading from r.
+func NewCellReader(r io.Reader) (*CellReader, error) {
+	cr := &CellReader{r: bufio.NewReader(r)}
+	header := make([]byte, len(cellsMagic)+3)
+	if _, err := io.ReadFull(cr.r, header); err != nil {
+		return nil, fmt.Errorf("cells invalid format. %v", err)
+	}
+	if string(header[:len(cellsMagic)]) != cellsMagic {
+		return nil, fmt.Errorf("cells invalid format. unknown magic %q", header[:len(cellsMagic)])
+	}
+	if version := header[len(cellsMagic)]; version != cellsVersion {
+		return nil, fmt.Errorf("cells invalid format. unknown version %d", version)
+	}
+	cr.compacted = header[len(cellsMagic)+1]&flagCompacted != 0
+	cr.resolution = int(header[len(cellsMagic)+2])
+	if cr.resolution > 15 {
+		return nil, fmt.Errorf("cells invalid format. got invalid resolution %d", cr.resolution)
+	}
+	count, err := binary.ReadUvarint(cr.r)
+	if err != nil {
+		return nil, fmt.Errorf("cells invalid format. %v", err)
+	}
+	if count > math.MaxInt {
+		return nil, fmt.Errorf("cells invalid format. got invalid count %d", count)
+	}
+	cr.count = count
+	return cr, nil
+}
+
+// Len returns the number of stored hexagons. For a compacted set
+// it is less than the number of hexagons returned by Next.
+func (r *CellReader) Len() int {
+	return int(r.count)
+}
+
+// Next returns the next hexagon, or io.EOF when there are no more hexagons.
+// The hexagons of a compacted set are uncompacted to the original resolution
+// and returned in the storage order, which is sorted by the compacted hexagons.
+func (r *CellReader) Next() (h3.H3Index, error) {
+	if r.child != 0 {
+		index := r.child
+		r.child = nextChild(r.parent, index)
+		return index, nil
+	}
+	if r.read >= r.count {
+		return 0, io.EOF
+	}
+	delta, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, fmt.Errorf("cells invalid format. %v", err)
+	}
+	r.read++
+	r.prev += delta
+	index := h3.H3Index(r.prev)
+	if !h3.IsValid(index) {
+		return 0, fmt.Errorf("cells invalid format. got invalid h3 index %x", r.prev)
+	}
+	if r.compacted && h3.Resolution(index) > r.resolution {
+		return 0, fmt.Errorf("cells invalid format. got resolution %d, expected <= %d",
+			h3.Resolution(index), r.resolution)
+	}
+	if r.maxCells > 0 {
+		r.decoded += maxChildren(r.resolution - h3.Resolution(index))
+		if r.decoded > r.maxCells {
+			return 0, fmt.Errorf("%w. got up to %d hexagons, expected <= %d hexagons", ErrMaxCells,
+				r.decoded, r.maxCells)
+		}
+	}
+	if r.compacted && h3.Resolution(index) < r.resolution {
+		r.parent = index
+		r.child = h3.ToCenterChild(index, r.resolution)
+		return r.Next()
+	}
+	return index, nil
+}
+
+// maxChildren returns the number of children of a hexagon levels finer,
+// the pentagons have fewer children.
+func maxChildren(levels int) uint64 {
+	n := uint64(1)
+	for i := 0; i < levels; i++ {
+		n *= 7
+	}
+	return n
+}
+
+// nextChild returns the child of the parent following the child in the index order,
+// or zero after the last child. The children are enumerated by incrementing
+// their digits, skipping the deleted subsequence of the pentagons.
+func nextChild(parent, child h3.H3Index) h3.H3Index {
+	parentRes, res := h3.Resolution(parent), h3.Resolution(child)
+	for {
+		r := res
+		for ; r > parentRes; r-- {
+			shift := uint(15-r) * 3
+			digit := (uint64(child) >> shift) & 7
+			child = h3.H3Index(uint64(child) &^ (7 << shift))
+			if digit < 6 {
+				child = h3.H3Index(uint64(child) | (digit+1)<<shift)
+				break
+			}
+		}
+		if r == parentRes {
+			return 0
+		}
+		if h3.IsValid(child) {
+			return child
+		}
+	}
+}
+
+func sortCells(indexes []h3.H3Index) []h3.H3Index {
+	cells := make([]h3.H3Index, len(indexes))
+	copy(cells, indexes)
+	sort.Slice(cells, func(i, j int) bool { return cells[i] < cells[j] })
+	n := 0
+	for i, index := range cells {
+		if i > 0 && index == cells[n-1] {
+			continue
+		}
+		cells[n] = index
+		n++
+	}
+	return cells[:n]
+}
+
+func appendUvarint(dst []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(dst, buf[:n]...)
+}
@@ -0,0 +1,177 @@
+package geojson2h3
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestMarshalCells(t *testing.T) {
+	points := strToPoints(`
+[-74.0, 40.7],
+[-73.9, 40.7],
+[-73.9, 40.8],
+[-74.0, 40.8],
+[-74.0, 40.7]
+`)
+	indexes, err := ToH3(10, geojson.NewPolygon(geometry.NewPoly(points, nil, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, compact := range []bool{false, true} {
+		data, err := MarshalCells(append(indexes, indexes[0]), compact)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, max := len(data), 4*len(indexes); have > max {
+			t.Fatalf("compact: %v, have %d bytes, want <= %d", compact, have, max)
+		}
+		have, err := UnmarshalCells(data, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSameIndexes(t, indexes, have)
+		for i := 1; i < len(have); i++ {
+			if have[i-1] >= have[i] {
+				t.Fatalf("compact: %v, indexes are not sorted", compact)
+			}
+		}
+	}
+}
+
+func TestCellReader(t *testing.T) {
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}, 5)
+	indexes := h3.ToChildren(origin, 8)
+	data, err := MarshalCells(indexes, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewCellReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 1, r.Len(); want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	have := make([]h3.H3Index, 0)
+	for {
+		index, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		have = append(have, index)
+	}
+	assertSameIndexes(t, indexes, have)
+
+	empty, err := MarshalCells(nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cells, err := UnmarshalCells(empty, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cells) != 0 {
+		t.Fatalf("have %d, want 0", len(cells))
+	}
+}
+
+func TestInvalidCells(t *testing.T) {
+	a := h3.FromGeo(h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}, 5)
+	b := h3.FromGeo(h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}, 6)
+	if _, err := MarshalCells([]h3.H3Index{a, b}, true); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	if _, err := MarshalCells([]h3.H3Index{42}, false); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	data, err := MarshalCells([]h3.H3Index{a, b}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := [][]byte{
+		nil,
+		[]byte("XXXX\x01\x00\x00\x00"),
+		[]byte("H3CS\x02\x00\x00\x00"),
+		[]byte("H3CS\x01\x00\x10\x00"),
+		[]byte("H3CS\x01\x00\x00"),
+		[]byte("H3CS\x01\x00\x00\x01\x2a"),
+		data[:len(data)-1],
+		[]byte("H3CS\x01\x00\x00\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01"),
+		[]byte("H3CS\x01\x00\x00\xff\xff\xff\xff\x0f\x2a"),
+		appendUvarint([]byte("H3CS\x01\x01\x05\x01"), uint64(b)),
+	}
+	for i, data := range invalid {
+		if _, err := UnmarshalCells(data, 0); err == nil {
+			t.Fatalf("case %d: have nil, expected error", i)
+		}
+	}
+}
+
+func TestMaxCellsUnmarshalCells(t *testing.T) {
+	parent := h3.FromGeo(h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}, 2)
+	indexes := h3.ToChildren(parent, 5)
+	data, err := MarshalCells(indexes, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := UnmarshalCells(data, len(indexes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, indexes, have)
+	if _, err := UnmarshalCells(data, len(indexes)-1); !errors.Is(err, ErrMaxCells) {
+		t.Fatalf("have %v, want %v", err, ErrMaxCells)
+	}
+	// a resolution 0 hexagon stored for resolution 15 expands to 7^15 hexagons
+	huge := appendUvarint([]byte("H3CS\x01\x01\x0f\x01"), uint64(h3.GetRes0Indexes()[0]))
+	if _, err := UnmarshalCells(huge, 10000000); !errors.Is(err, ErrMaxCells) {
+		t.Fatalf("have %v, want %v", err, ErrMaxCells)
+	}
+	if _, err := UnmarshalCells(data, -1); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+}
+
+func TestCellReaderChildren(t *testing.T) {
+	for _, parent := range []h3.H3Index{
+		h3.FromGeo(h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}, 2),
+		h3.GetPentagonIndexes(2)[0],
+	} {
+		data, err := MarshalCells(h3.ToChildren(parent, 5), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		have, err := UnmarshalCells(data, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSameIndexes(t, h3.ToChildren(parent, 5), have)
+	}
+
+	// the children are iterated without uncompacting the whole subtree
+	data := appendUvarint([]byte("H3CS\x01\x01\x0f\x01"), uint64(h3.GetRes0Indexes()[0]))
+	r, err := NewCellReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := h3.H3Index(0)
+	for i := 0; i < 100; i++ {
+		index, err := r.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h3.Resolution(index) != 15 || index <= prev {
+			t.Fatalf("have %s, want sorted children at resolution 15", h3.ToString(index))
+		}
+		prev = index
+	}
+}
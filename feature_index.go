@@ -0,0 +1,169 @@
+package geojson2h3
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+// FeatureMatch is a feature found by a FeatureIndex search.
+type FeatureMatch struct {
+	Feature *geojson.Feature
+	// Position is the position of the feature in the FeatureCollection.
+	Position int
+	// Distance is the ring distance from the origin hexagon to the nearest
+	// hexagon of the feature, zero if the feature covers the origin.
+	Distance int
+	// Index is the nearest hexagon of the feature.
+	Index h3.H3Index
+}
+
+// FeatureIndex is a searchable set of features by their hexagons.
+type FeatureIndex struct {
+	Resolution int
+	features   []*geojson.Feature
+	cells      map[h3.H3Index][]int
+	indexed    int
+}
+
+// ToFeatureIndex converts the features of a GeoJSON FeatureCollection
+// to hexagons with specified resolution and indexes them for searching.
+// See Converter.ToFeatureIndex for the details.
+func ToFeatureIndex(resolution int, o geojson.Object) (*FeatureIndex, error) {
+	c := Converter{Resolution: resolution}
+	return c.ToFeatureIndex(o)
+}
+
+// ToFeatureIndex converts each feature of a GeoJSON FeatureCollection
+// to hexagons and indexes them for searching. See ToH3 for the list
+// of known feature geometries. MaxCells limits the number of hexagons
+// of each feature, Compact is ignored.
+func (c *Converter) ToFeatureIndex(o geojson.Object) (*FeatureIndex, error) {
+	if o == nil {
+		return nil, fmt.Errorf("geojson.Object is nil")
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	collection, ok := o.(*geojson.FeatureCollection)
+	if !ok {
+		return nil, fmt.Errorf("GeoJSON invalid format. expected geojson.FeatureCollection, got %T", o)
+	}
+	conv := *c
+	conv.Compact = false
+	x := &FeatureIndex{
+		Resolution: c.Resolution,
+		features:   make([]*geojson.Feature, 0),
+		cells:      make(map[h3.H3Index][]int),
+	}
+	var err error
+	collection.ForEach(func(geom geojson.Object) bool {
+		feature, isFeature := geom.(*geojson.Feature)
+		if !isFeature {
+			err = fmt.Errorf("GeoJSON invalid format. expected geojson.Feature, got %T", geom)
+			return false
+		}
+		var indexes []h3.H3Index
+		indexes, err = conv.ToH3(feature)
+		if err != nil {
+			return false
+		}
+		position := len(x.features)
+		x.features = append(x.features, feature)
+		for _, index := range sortCells(indexes) {
+			x.cells[index] = append(x.cells[index], position)
+		}
+		if len(indexes) > 0 {
+			x.indexed++
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// Len returns the number of features.
+func (x *FeatureIndex) Len() int {
+	return len(x.features)
+}
+
+// Nearest returns up to k features nearest to the point, searching
+// the expanding rings around the hexagon of the point up to maxDistance rings.
+// The result is sorted by distance, then by position.
+func (x *FeatureIndex) Nearest(point geometry.Point, k, maxDistance int) ([]FeatureMatch, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("got invalid k %d. expected > 0", k)
+	}
+	if maxDistance < 0 {
+		return nil, fmt.Errorf("got invalid max distance %d. expected >= 0", maxDistance)
+	}
+	matches := x.search(point, maxDistance, k)
+	if len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches, nil
+}
+
+// Within returns the features within k rings of the hexagon of the point.
+// The result is sorted by distance, then by position.
+func (x *FeatureIndex) Within(point geometry.Point, k int) ([]FeatureMatch, error) {
+	if k < 0 {
+		return nil, fmt.Errorf("got invalid k %d. expected >= 0", k)
+	}
+	return x.search(point, k, 0), nil
+}
+
+// search visits the rings around the hexagon of the point breadth-first,
+// so the ring distance is exact around the pentagons too. It stops after
+// maxDistance rings, all features found or, if limit is positive,
+// the ring with at least limit features found.
+func (x *FeatureIndex) search(point geometry.Point, maxDistance, limit int) []FeatureMatch {
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: point.Y, Longitude: point.X}, x.Resolution)
+	matches := make([]FeatureMatch, 0)
+	found := make(map[int]struct{})
+	visits := map[h3.H3Index]struct{}{origin: {}}
+	ring := []h3.H3Index{origin}
+	for distance := 0; ; distance++ {
+		for _, index := range sortCells(ring) {
+			for _, position := range x.cells[index] {
+				if _, ok := found[position]; ok {
+					continue
+				}
+				found[position] = struct{}{}
+				matches = append(matches, FeatureMatch{
+					Feature:  x.features[position],
+					Position: position,
+					Distance: distance,
+					Index:    index,
+				})
+			}
+		}
+		if distance == maxDistance || len(found) == x.indexed ||
+			(limit > 0 && len(matches) >= limit) {
+			break
+		}
+		next := make([]h3.H3Index, 0, len(ring)+6)
+		for _, index := range ring {
+			for _, neighbour := range neighbours(index) {
+				if _, ok := visits[neighbour]; ok {
+					continue
+				}
+				visits[neighbour] = struct{}{}
+				next = append(next, neighbour)
+			}
+		}
+		ring = next
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return matches[i].Position < matches[j].Position
+	})
+	return matches
+}
@@ -0,0 +1,134 @@
+package geojson2h3
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+const featureIndexCollection = `{"type":"FeatureCollection","features":[
+{"type":"Feature","properties":{"name":"far"},"geometry":{"type":"Point","coordinates":[-73.90,40.75]}},
+{"type":"Feature","properties":{"name":"park"},"geometry":{"type":"Polygon","coordinates":[[[-73.96,40.74],[-73.94,40.74],[-73.94,40.76],[-73.96,40.76],[-73.96,40.74]]]}},
+{"type":"Feature","properties":{"name":"near"},"geometry":{"type":"Point","coordinates":[-73.93,40.75]}},
+{"type":"Feature","properties":{"name":"route"},"geometry":{"type":"LineString","coordinates":[[-73.95,40.70],[-73.95,40.80]]}}
+]}`
+
+func TestFeatureIndexNearest(t *testing.T) {
+	o, err := geojson.Parse(featureIndexCollection, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := ToFeatureIndex(9, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Len() != 4 {
+		t.Fatalf("have %d, want %d", x.Len(), 4)
+	}
+	point := geometry.Point{X: -73.93, Y: 40.75}
+	matches, err := x.Nearest(point, 2, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("have %d, want %d", len(matches), 2)
+	}
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: point.Y, Longitude: point.X}, 9)
+	if matches[0].Position != 2 || matches[0].Distance != 0 || matches[0].Index != origin {
+		t.Fatalf("have %+v, want the near point at distance 0", matches[0])
+	}
+	if matches[1].Position != 1 || matches[1].Distance == 0 {
+		t.Fatalf("have %+v, want the park", matches[1])
+	}
+	if d := h3.DistanceBetween(origin, matches[1].Index); d != matches[1].Distance {
+		t.Fatalf("have %d, want %d", matches[1].Distance, d)
+	}
+	all, err := x.Nearest(point, 10, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("have %d, want %d", len(all), 4)
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Distance > all[i].Distance {
+			t.Fatalf("have unsorted distances %d and %d", all[i-1].Distance, all[i].Distance)
+		}
+	}
+	none, err := x.Nearest(geometry.Point{X: 0, Y: 0}, 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(none) != 0 {
+		t.Fatalf("have %d, want %d", len(none), 0)
+	}
+}
+
+func TestFeatureIndexWithin(t *testing.T) {
+	o, err := geojson.Parse(featureIndexCollection, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := ToFeatureIndex(9, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	point := geometry.Point{X: -73.93, Y: 40.75}
+	nearest, err := x.Nearest(point, 4, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, match := range nearest {
+		within, err := x.Within(point, match.Distance)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, other := range within {
+			if other.Distance > match.Distance {
+				t.Fatalf("have %d, want <= %d", other.Distance, match.Distance)
+			}
+		}
+		if within[len(within)-1].Distance != match.Distance {
+			t.Fatalf("have %d, want %d", within[len(within)-1].Distance, match.Distance)
+		}
+	}
+	within, err := x.Within(point, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(within) != 1 || within[0].Position != 2 {
+		t.Fatalf("have %+v, want the near point", within)
+	}
+}
+
+func TestInvalidFeatureIndex(t *testing.T) {
+	if _, err := ToFeatureIndex(9, nil); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	point := geojson.NewPoint(geometry.Point{X: -73.93, Y: 40.75})
+	if _, err := ToFeatureIndex(9, point); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	o, err := geojson.Parse(featureIndexCollection, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ToFeatureIndex(16, o); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	x, err := ToFeatureIndex(9, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := x.Nearest(point.Base(), 0, 10); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := x.Nearest(point.Base(), 1, -1); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := x.Within(point.Base(), -1); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
@@ -0,0 +1,154 @@
+package geojson2h3
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+// evenOddToH3 converts the MultiPolygon with the even-odd fill rule:
+// a point is within the MultiPolygon if it is within an odd number of rings.
+func (c *Converter) evenOddToH3(multi *geojson.MultiPolygon) ([]h3.H3Index, Perimeter, error) {
+	exteriors := make([]*geojson.Polygon, 0)
+	rings := make([]geometry.Ring, 0)
+	multi.ForEach(func(geom geojson.Object) bool {
+		polygon, ok := geom.(*geojson.Polygon)
+		if !ok {
+			return true
+		}
+		exteriors = append(exteriors, geojson.NewPolygon(geometry.NewPoly(
+			ringPoints(polygon.Base().Exterior), nil, &geometry.IndexOptions{
+				Kind: geometry.None,
+			})))
+		rings = append(rings, polygon.Base().Exterior)
+		rings = append(rings, polygon.Base().Holes...)
+		return true
+	})
+	for _, exterior := range exteriors {
+		if err := c.checkEstimate(exterior); err != nil {
+			return nil, Perimeter{}, err
+		}
+	}
+	points := make([][]geometry.Point, len(rings))
+	for i, ring := range rings {
+		points[i] = ringPoints(ring)
+	}
+	return c.fill(multi.Center(), func(containment Containment) ([]h3.H3Index, error) {
+		candidates := make(map[h3.H3Index]float64)
+		for _, exterior := range exteriors {
+			if containment == ContainmentCenter {
+				for _, index := range h3.Polyfill(toGeoPolygon(exterior), c.Resolution) {
+					candidates[index] = 1
+				}
+				continue
+			}
+			if err := polygonToH3Coverage(c.Resolution, exterior, candidates); err != nil {
+				return nil, err
+			}
+		}
+		indexes := make([]h3.H3Index, 0, len(candidates))
+		for index := range candidates {
+			if containment == ContainmentCenter {
+				if evenOddContains(points, h3.ToGeo(index)) {
+					indexes = append(indexes, index)
+				}
+				continue
+			}
+			fraction := evenOddCoverage(index, rings, points)
+			if fraction <= 1-fullCoverage ||
+				(containment == ContainmentFull && fraction < fullCoverage) {
+				continue
+			}
+			indexes = append(indexes, index)
+		}
+		sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+		return indexes, nil
+	})
+}
+
+func ringPoints(ring geometry.Ring) []geometry.Point {
+	points := make([]geometry.Point, 0, ring.NumPoints())
+	for i := 0; i < ring.NumPoints(); i++ {
+		points = append(points, ring.PointAt(i))
+	}
+	return points
+}
+
+// evenOddCoverage returns the fraction of the hexagon area within an odd number of rings.
+// It is exact when at most one ring crosses the hexagon, otherwise it is estimated
+// by the centers of the hexagon descendants.
+func evenOddCoverage(index h3.H3Index, rings []geometry.Ring, points [][]geometry.Point) float64 {
+	rect := cellRect(index)
+	odd := false
+	crossing, fraction := 0, float64(0)
+	for _, ring := range rings {
+		if !ring.Rect().IntersectsRect(rect) {
+			continue
+		}
+		f := cellCoverage(index, []geometry.Ring{ring})
+		switch {
+		case f >= fullCoverage:
+			odd = !odd
+		case f > 1-fullCoverage:
+			crossing++
+			fraction = f
+		}
+	}
+	switch {
+	case crossing == 0 && odd:
+		return 1
+	case crossing == 0:
+		return 0
+	case crossing == 1 && odd:
+		return 1 - fraction
+	case crossing == 1:
+		return fraction
+	}
+	resolution := h3.Resolution(index) + evenOddSampleLevels
+	if resolution > 15 {
+		resolution = 15
+	}
+	samples := h3.ToChildren(index, resolution)
+	inside := 0
+	for _, sample := range samples {
+		if evenOddContains(points, h3.ToGeo(sample)) {
+			inside++
+		}
+	}
+	return float64(inside) / float64(len(samples))
+}
+
+// evenOddContains reports whether the coordinate is within an odd number of rings.
+func evenOddContains(rings [][]geometry.Point, coord h3.GeoCoord) bool {
+	point := geometry.Point{X: coord.Longitude, Y: coord.Latitude}
+	odd := false
+	for _, ring := range rings {
+		if ringContains(ring, point) {
+			odd = !odd
+		}
+	}
+	return odd
+}
+
+// evenOddSampleLevels is the resolution difference of the descendants
+// sampled by evenOddCoverage, i.e. 343 samples per hexagon.
+const evenOddSampleLevels = 3
+
+// cellRect returns the bounding box of the hexagon.
+func cellRect(index h3.H3Index) geometry.Rect {
+	boundary := h3.ToGeoBoundary(index)
+	rect := geometry.Rect{
+		Min: geometry.Point{X: math.Inf(1), Y: math.Inf(1)},
+		Max: geometry.Point{X: math.Inf(-1), Y: math.Inf(-1)},
+	}
+	for _, b := range boundary {
+		rect.Min.X = math.Min(rect.Min.X, b.Longitude)
+		rect.Min.Y = math.Min(rect.Min.Y, b.Latitude)
+		rect.Max.X = math.Max(rect.Max.X, b.Longitude)
+		rect.Max.Y = math.Max(rect.Max.Y, b.Latitude)
+	}
+	return rect
+}
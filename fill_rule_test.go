@@ -0,0 +1,127 @@
+package geojson2h3
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func square(x, y, half float64) []geometry.Point {
+	return []geometry.Point{
+		{X: x - half, Y: y - half},
+		{X: x + half, Y: y - half},
+		{X: x + half, Y: y + half},
+		{X: x - half, Y: y + half},
+		{X: x - half, Y: y - half},
+	}
+}
+
+type probe struct {
+	x, y float64
+	want bool
+}
+
+func assertProbes(t *testing.T, resolution int, indexes []h3.H3Index, probes []probe) {
+	t.Helper()
+	set := make(map[h3.H3Index]struct{}, len(indexes))
+	for _, index := range indexes {
+		set[index] = struct{}{}
+	}
+	for _, p := range probes {
+		index := h3.FromGeo(h3.GeoCoord{Latitude: p.y, Longitude: p.x}, resolution)
+		if _, have := set[index]; have != p.want {
+			t.Fatalf("have %v, want %v at [%f, %f]", have, p.want, p.x, p.y)
+		}
+	}
+}
+
+func TestNestedRingsToH3(t *testing.T) {
+	const x, y = -73.95, 40.75
+	land := geometry.NewPoly(square(x, y, 0.1), [][]geometry.Point{square(x, y, 0.05)}, nil)
+	island := geometry.NewPoly(square(x, y, 0.02), nil, nil)
+	lakeInLand := geojson.NewMultiPolygon([]*geometry.Poly{land, island})
+	// the lake is a part instead of a hole
+	lakePart := geojson.NewMultiPolygon([]*geometry.Poly{
+		geometry.NewPoly(square(x, y, 0.1), nil, nil),
+		geometry.NewPoly(square(x, y, 0.05), nil, nil),
+		island,
+	})
+	probes := []probe{
+		{x: x, y: y, want: true},                 // island
+		{x: x + 0.035, y: y, want: false},        // lake
+		{x: x + 0.075, y: y + 0.075, want: true}, // land
+		{x: x + 0.15, y: y, want: false},         // outside
+	}
+	for _, containment := range []Containment{ContainmentCenter, ContainmentIntersects, ContainmentFull} {
+		for _, multi := range []*geojson.MultiPolygon{lakeInLand, lakePart} {
+			conv := &Converter{Resolution: 8, Containment: containment, FillRule: FillRuleEvenOdd}
+			indexes, err := conv.ToH3(multi)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertProbes(t, 8, indexes, probes)
+		}
+	}
+	// the union semantics fills the lake given as a part
+	indexes, err := ToH3(8, lakePart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertProbes(t, 8, indexes, []probe{{x: x + 0.035, y: y, want: true}})
+	// and keeps the island within the hole
+	indexes, err = ToH3(8, lakeInLand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertProbes(t, 8, indexes, probes)
+}
+
+func TestOverlappingPartsToH3(t *testing.T) {
+	const x, y = -73.95, 40.75
+	multi := geojson.NewMultiPolygon([]*geometry.Poly{
+		geometry.NewPoly(square(x, y, 0.05), nil, nil),
+		geometry.NewPoly(square(x+0.05, y, 0.05), nil, nil),
+	})
+	probes := []probe{
+		{x: x - 0.03, y: y, want: true},
+		{x: x + 0.025, y: y, want: false},
+		{x: x + 0.08, y: y, want: true},
+	}
+	conv := &Converter{Resolution: 8, FillRule: FillRuleEvenOdd}
+	indexes, err := conv.ToH3(multi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertProbes(t, 8, indexes, probes)
+	conv.Containment = ContainmentIntersects
+	indexes, err = conv.ToH3(multi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertProbes(t, 8, indexes, probes)
+}
+
+func TestEvenOddFallbackToH3(t *testing.T) {
+	const x, y = -73.95, 40.75
+	multi := geojson.NewMultiPolygon([]*geometry.Poly{
+		geometry.NewPoly(square(x, y, 0.0001), nil, nil),
+	})
+	conv := &Converter{Resolution: 5, FillRule: FillRuleEvenOdd}
+	indexes, err := conv.ToH3(multi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indexes) != 1 {
+		t.Fatalf("have %d, want %d", len(indexes), 1)
+	}
+	conv.Fallback = FallbackError
+	if _, err := conv.ToH3(multi); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	conv.FillRule = FillRule(10)
+	if _, err := conv.ToH3(multi); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
@@ -25,111 +25,124 @@ import (
 // Note that conversion from GeoJSON
 // * is lossy; the resulting hexagon set only approximately describes the original
 // * shape, at a level of precision determined by the hexagon resolution.
+//
+// Use Converter to configure the conversion.
 func ToH3(resolution int, o geojson.Object) (indexes []h3.H3Index, err error) {
-	if o == nil {
-		return nil, fmt.Errorf("geojson.Object is nil")
-	}
-	if resolution < 0 || resolution > 15 {
-		return nil, fmt.Errorf("got invalid resolution %d. expected from 0 to 15",
-			resolution)
+	c := Converter{Resolution: resolution}
+	return c.ToH3(o)
+}
+
+// convert converts the geometries of the object one by one. The perimeter
+// holds the perimeters of the polygons, Rect and Circle if Perimeter is enabled.
+func (c *Converter) convert(o geojson.Object) ([]h3.H3Index, Perimeter, error) {
+	acc := c.newAccumulator()
+	var perimeter Perimeter
+	err := forEachGeometry(o, func(geom geojson.Object, _ *geojson.Feature) error {
+		indexes, geomPerimeter, err := c.polyfill(geom)
+		if err != nil {
+			return err
+		}
+		perimeter.add(geomPerimeter)
+		return acc.add(indexes)
+	})
+	if err != nil {
+		return nil, Perimeter{}, err
 	}
+	return acc.indexes, perimeter, nil
+}
 
+// forEachGeometry calls fn with each geometry of the object and its feature,
+// if any, descending into the FeatureCollection, GeometryCollection and Feature
+// objects. It stops at the first error.
+func forEachGeometry(o geojson.Object, fn func(geom geojson.Object, feature *geojson.Feature) error) error {
+	return walkGeometry(o, nil, fn)
+}
+
+func walkGeometry(o geojson.Object, feature *geojson.Feature,
+	fn func(geom geojson.Object, feature *geojson.Feature) error) (err error) {
 	switch typ := o.(type) {
 	case *geojson.FeatureCollection:
-		set := make([][]h3.H3Index, 0)
 		typ.ForEach(func(geom geojson.Object) bool {
-			feature, ok := geom.(*geojson.Feature)
+			f, ok := geom.(*geojson.Feature)
 			if !ok {
 				err = fmt.Errorf("GeoJSON invalid format. expected geojson.Feature, got %T", geom)
 				return false
 			}
-			indexes, err = polyfill(resolution, feature.Base())
-			if err != nil {
-				return false
-			}
-			set = append(set, indexes)
-			return true
+			err = walkGeometry(f.Base(), f, fn)
+			return err == nil
 		})
-		indexes = deDup(set)
 	case *geojson.GeometryCollection:
-		set := make([][]h3.H3Index, 0)
 		typ.ForEach(func(geom geojson.Object) bool {
-			indexes, err = polyfill(resolution, geom)
-			if err != nil {
-				return false
-			}
-			set = append(set, indexes)
-			return true
+			err = walkGeometry(geom, feature, fn)
+			return err == nil
 		})
-		indexes = deDup(set)
 	case *geojson.Feature:
-		indexes, err = polyfill(resolution, typ.Base())
+		err = walkGeometry(typ.Base(), typ, fn)
 	default:
-		indexes, err = polyfill(resolution, o)
+		err = fn(o, feature)
 	}
 	return
 }
 
-func polyfill(resolution int, o geojson.Object) (indexes []h3.H3Index, err error) {
+func (c *Converter) polyfill(o geojson.Object) (indexes []h3.H3Index, perimeter Perimeter, err error) {
+	resolution := c.Resolution
 	switch typ := o.(type) {
 	case *geojson.MultiPoint:
-		set := make([][]h3.H3Index, 0)
+		acc := c.newAccumulator()
 		typ.ForEach(func(object geojson.Object) bool {
 			point, ok := object.(*geojson.Point)
 			if !ok {
 				return false
 			}
-			indexes := pointToH3(resolution, point)
-			set = append(set, indexes)
-			return true
+			err = acc.add(pointToH3(resolution, point))
+			return err == nil
 		})
-		indexes = deDup(set)
+		indexes = acc.indexes
 	case *geojson.Rect:
-		return rectToH3(resolution, typ), nil
+		return c.rectToH3(typ)
 	case *geojson.SimplePoint:
-		return simplePointToH3(resolution, typ), nil
+		return simplePointToH3(resolution, typ), Perimeter{}, nil
 	case *geojson.Point:
-		return pointToH3(resolution, typ), nil
+		return pointToH3(resolution, typ), Perimeter{}, nil
 	case *geojson.Circle:
-		return circleToH3(resolution, typ)
+		return c.circleToH3(typ)
 	case *geojson.MultiLineString:
-		set := make([][]h3.H3Index, 0)
+		acc := c.newAccumulator()
 		typ.ForEach(func(geom geojson.Object) bool {
 			lineString, ok := geom.(*geojson.LineString)
 			if !ok {
 				return false
 			}
-			indexes, err = lineStringToH3(resolution, lineString)
-			if err != nil {
-				return false
-			}
-			set = append(set, indexes)
-			return true
+			err = c.lineToH3(lineString.Base(), acc)
+			return err == nil
 		})
-		indexes = deDup(set)
+		indexes = acc.indexes
 	case *geojson.LineString:
-		indexes, err = lineStringToH3(resolution, typ)
-		if err != nil {
-			return nil, err
-		}
-		indexes = deDup([][]h3.H3Index{indexes})
+		acc := c.newAccumulator()
+		err = c.lineToH3(typ.Base(), acc)
+		indexes = acc.indexes
 	case *geojson.Polygon:
-		indexes, err = polygonToH3(resolution, typ)
+		indexes, perimeter, err = c.polygonToH3(typ)
 	case *geojson.MultiPolygon:
-		set := make([][]h3.H3Index, 0)
+		if c.FillRule == FillRuleEvenOdd {
+			return c.evenOddToH3(typ)
+		}
+		acc := c.newAccumulator()
 		typ.ForEach(func(geom geojson.Object) bool {
 			polygon, ok := geom.(*geojson.Polygon)
 			if !ok {
 				return false
 			}
-			indexes, err = polygonToH3(resolution, polygon)
+			var polygonPerimeter Perimeter
+			indexes, polygonPerimeter, err = c.polygonToH3(polygon)
 			if err != nil {
 				return false
 			}
-			set = append(set, indexes)
-			return true
+			perimeter.add(polygonPerimeter)
+			err = acc.add(indexes)
+			return err == nil
 		})
-		indexes = deDup(set)
+		indexes = acc.indexes
 	default:
 		err = fmt.Errorf("unknown GeoJSON object")
 	}
@@ -152,45 +165,8 @@ func simplePointToH3(resolution int, point *geojson.SimplePoint) []h3.H3Index {
 	return []h3.H3Index{index}
 }
 
-func rectToH3(resolution int, rect *geojson.Rect) []h3.H3Index {
-	poly := h3.GeoPolygon{}
-	poly.Geofence = make([]h3.GeoCoord, 0, rect.NumPoints())
-	for i := 0; i < rect.Base().NumPoints(); i++ {
-		point := rect.Base().PointAt(i)
-		poly.Geofence = append(poly.Geofence, h3.GeoCoord{
-			Latitude:  point.Y,
-			Longitude: point.X,
-		})
-	}
-	indexes := h3.Polyfill(poly, resolution)
-	if len(indexes) == 0 {
-		indexes = pointToH3(resolution, geojson.NewPoint(rect.Center()))
-	}
-	return indexes
-}
-
-func circleToH3(resolution int, circle *geojson.Circle) ([]h3.H3Index, error) {
-	poly := h3.GeoPolygon{}
-	poly.Geofence = make([]h3.GeoCoord, 0, circle.NumPoints())
-	polygon, ok := circle.Primative().(*geojson.Polygon)
-	if !ok {
-		return nil, fmt.Errorf("expected geojson.Polygon, got %T", polygon)
-	}
-	for i := 0; i < polygon.Base().Exterior.NumPoints(); i++ {
-		point := polygon.Base().Exterior.PointAt(i)
-		poly.Geofence = append(poly.Geofence, h3.GeoCoord{
-			Latitude:  point.Y,
-			Longitude: point.X,
-		})
-	}
-	indexes := h3.Polyfill(poly, resolution)
-	if len(indexes) == 0 {
-		indexes = pointToH3(resolution, geojson.NewPoint(circle.Center()))
-	}
-	return indexes, nil
-}
-
-func polygonToH3(resolution int, polygon *geojson.Polygon) ([]h3.H3Index, error) {
+// toGeoPolygon returns the polygon rings as lat/lng coordinates.
+func toGeoPolygon(polygon *geojson.Polygon) h3.GeoPolygon {
 	poly := h3.GeoPolygon{}
 	poly.Geofence = make([]h3.GeoCoord, 0, polygon.NumPoints())
 	numHoles := len(polygon.Base().Holes)
@@ -214,67 +190,61 @@ func polygonToH3(resolution int, polygon *geojson.Polygon) ([]h3.H3Index, error)
 			Longitude: point.X,
 		})
 	}
-	indexes := h3.Polyfill(poly, resolution)
-	if len(indexes) == 0 {
-		indexes = pointToH3(resolution, geojson.NewPoint(polygon.Center()))
-	}
-	return indexes, nil
+	return poly
+}
+
+// segmenter is a series of line segments.
+type segmenter interface {
+	NumPoints() int
+	NumSegments() int
+	SegmentAt(index int) geometry.Segment
 }
 
-func lineStringToH3(resolution int, lineString *geojson.LineString) ([]h3.H3Index, error) {
-	if lineString.Base().NumPoints() < 2 {
-		return nil, fmt.Errorf("got %d points, expected >= 2 points",
-			lineString.Base().NumPoints())
+// lineToH3 samples the line every LineStep meters, adding the hexagons
+// of the samples to the accumulator one by one, so a long line
+// stops at MaxCells before all samples are generated.
+func (c *Converter) lineToH3(line segmenter, acc *cellAccumulator) error {
+	if line.NumPoints() < 2 {
+		return fmt.Errorf("got %d points, expected >= 2 points",
+			line.NumPoints())
 	}
-	step := stepForResolution(resolution)
-	points := make([]geometry.Point, 0, 2)
-	for i := 0; i < lineString.Base().NumSegments(); i++ {
-		segment := lineString.Base().SegmentAt(i)
-		dist := distanceMeters(segment)
-		if dist > step {
-			points = append(points, segment.A)
-			b := bearing(segment)
-			for j := float64(0); j < dist; j += step {
-				next := j
-				if next+step > dist {
-					next = dist
-				}
-				lat, lon := geo.DestinationPoint(segment.A.Y, segment.A.X, next, b)
-				points = append(points, geometry.Point{X: lon, Y: lat})
-			}
-		} else {
-			points = append(points, segment.A)
-			points = append(points, segment.B)
+	step := c.lineStep()
+	for i := 0; i < line.NumSegments(); i++ {
+		err := sampleSegment(line.SegmentAt(i), step, func(point geometry.Point, _ float64) error {
+			return acc.addIndex(h3.FromGeo(h3.GeoCoord{
+				Latitude:  point.Y,
+				Longitude: point.X}, c.Resolution))
+		})
+		if err != nil {
+			return err
 		}
 	}
-	indexes := make([]h3.H3Index, 0, len(points))
-	for i := 0; i < len(points); i++ {
-		cellID := h3.FromGeo(h3.GeoCoord{
-			Latitude:  points[i].Y,
-			Longitude: points[i].X}, resolution)
-		indexes = append(indexes, cellID)
-	}
-	return indexes, nil
+	return nil
 }
 
-func deDup(indexes [][]h3.H3Index) []h3.H3Index {
-	if len(indexes) == 0 {
-		return []h3.H3Index{}
+// sampleSegment calls fn with the points of the segment every step meters,
+// from A to B, and their distance from A. It stops at the first error.
+func sampleSegment(segment geometry.Segment, step float64,
+	fn func(point geometry.Point, distance float64) error) error {
+	dist := distanceMeters(segment)
+	if err := fn(segment.A, 0); err != nil {
+		return err
 	}
-	visits := make(map[h3.H3Index]struct{})
-	result := make([]h3.H3Index, 0)
-	for i := 0; i < len(indexes); i++ {
-		set := indexes[i]
-		for j := 0; j < len(set); j++ {
-			idx := set[j]
-			if _, ok := visits[idx]; ok {
-				continue
-			}
-			visits[idx] = struct{}{}
-			result = append(result, idx)
+	if dist <= step {
+		return fn(segment.B, dist)
+	}
+	b := bearing(segment)
+	for j := float64(0); j < dist; j += step {
+		next := j
+		if next+step > dist {
+			next = dist
+		}
+		lat, lon := geo.DestinationPoint(segment.A.Y, segment.A.X, next, b)
+		if err := fn(geometry.Point{X: lon, Y: lat}, next); err != nil {
+			return err
 		}
 	}
-	return result
+	return nil
 }
 
 func distanceMeters(s geometry.Segment) float64 {
@@ -304,30 +274,28 @@ const (
 	level15km = 0.0005
 )
 
-var steps = map[int]float64{
-	0:  level0km,
-	1:  level1km,
-	2:  level2km,
-	3:  level3km,
-	4:  level4km,
-	5:  level5km,
-	6:  level6km,
-	7:  level7km,
-	8:  level8km,
-	9:  level9km,
-	10: level10km,
-	11: level11km,
-	12: level12km,
-	13: level13km,
-	14: level14km,
-	15: level15km,
+// steps is the default sampling distance of the lines by resolution.
+var steps = [...]float64{
+	level0km,
+	level1km,
+	level2km,
+	level3km,
+	level4km,
+	level5km,
+	level6km,
+	level7km,
+	level8km,
+	level9km,
+	level10km,
+	level11km,
+	level12km,
+	level13km,
+	level14km,
+	level15km,
 }
 
+// stepForResolution returns the default sampling distance of the lines.
+// The resolution must be validated by the caller.
 func stepForResolution(level int) (meters float64) {
-	km, ok := steps[level]
-	if !ok {
-		km = level7km
-	}
-	meters = km * 1000
-	return
+	return steps[level] * 1000
 }
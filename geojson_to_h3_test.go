@@ -41,6 +41,39 @@ func TestRectToH3(t *testing.T) {
 	}
 }
 
+// The rect edges follow the parallels and meridians, since H3 fills
+// the polygons in lat/lng space.
+func TestHighLatitudeRectToH3(t *testing.T) {
+	res := 4
+	bounds := geometry.Rect{
+		Min: geometry.Point{X: 10, Y: 60},
+		Max: geometry.Point{X: 40, Y: 70},
+	}
+	indexes, err := ToH3(res, geojson.NewRect(bounds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := make(map[h3.H3Index]struct{}, len(indexes))
+	for _, index := range indexes {
+		center := h3.ToGeo(index)
+		if !bounds.ContainsPoint(geometry.Point{X: center.Longitude, Y: center.Latitude}) {
+			t.Fatalf("cell %s center is outside of the rect", h3.ToString(index))
+		}
+		set[index] = struct{}{}
+	}
+	for lat := bounds.Min.Y; lat <= bounds.Max.Y; lat += 0.05 {
+		for lon := bounds.Min.X; lon <= bounds.Max.X; lon += 0.05 {
+			index := h3.FromGeo(h3.GeoCoord{Latitude: lat, Longitude: lon}, res)
+			center := h3.ToGeo(index)
+			inside := center.Latitude > bounds.Min.Y && center.Latitude < bounds.Max.Y &&
+				center.Longitude > bounds.Min.X && center.Longitude < bounds.Max.X
+			if _, ok := set[index]; inside && !ok {
+				t.Fatalf("cell %s with center inside the rect is missing", h3.ToString(index))
+			}
+		}
+	}
+}
+
 func TestObjectAsNil(t *testing.T) {
 	_, err := ToH3(7, nil)
 	if err == nil {
@@ -0,0 +1,234 @@
+package geojson2h3
+
+import (
+	"fmt"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+// ShapeKind is the kind of a simple geometry.
+type ShapeKind int
+
+const (
+	PointShape ShapeKind = iota + 1
+	LineShape
+	PolygonShape
+)
+
+// Shape is a simple geometry expressed as rings of lat/lng coordinates:
+//  - PointShape: a single ring with a single coordinate
+//  - LineShape: a single ring with the line coordinates
+//  - PolygonShape: the exterior ring followed by the holes
+type Shape struct {
+	Kind  ShapeKind
+	Rings [][]h3.GeoCoord
+}
+
+// Geometry is a geometry model that can be converted to hexagons.
+// Implement it to plug in your own geometry types without converting them to GeoJSON.
+type Geometry interface {
+	// Shapes returns the points, lines and polygons of the geometry.
+	Shapes() ([]Shape, error)
+}
+
+// GeometryToH3 converts a geometry to a list of hexagons with specified resolution.
+// See Converter.GeometryToH3 for the details.
+func GeometryToH3(resolution int, g Geometry) ([]h3.H3Index, error) {
+	c := Converter{Resolution: resolution}
+	return c.GeometryToH3(g)
+}
+
+// GeometryToH3 converts a geometry to a list of hexagons with the converter options.
+// The shapes are converted the same way as the corresponding GeoJSON objects by ToH3:
+// the points, the lines and the polygons as parts of a single MultiPolygon,
+// so FillRule applies across all polygon shapes of the geometry.
+// The rings are used as they are, without converting the shapes to GeoJSON.
+func (c *Converter) GeometryToH3(g Geometry) ([]h3.H3Index, error) {
+	if g == nil {
+		return nil, fmt.Errorf("geometry is nil")
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	shapes, err := g.Shapes()
+	if err != nil {
+		return nil, err
+	}
+	acc := c.newAccumulator()
+	polygons := make([]Shape, 0)
+	for _, shape := range shapes {
+		if len(shape.Rings) == 0 || len(shape.Rings[0]) == 0 {
+			return nil, fmt.Errorf("got empty shape")
+		}
+		switch shape.Kind {
+		case PointShape:
+			err = acc.addIndex(h3.FromGeo(shape.Rings[0][0], c.Resolution))
+		case LineShape:
+			err = c.lineToH3(geoLine(shape.Rings[0]), acc)
+		case PolygonShape:
+			if len(shape.Rings[0]) < 3 {
+				return nil, fmt.Errorf("got %d points, expected >= 3 points",
+					len(shape.Rings[0]))
+			}
+			polygons = append(polygons, shape)
+		default:
+			return nil, fmt.Errorf("unknown shape kind %d", shape.Kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := c.polygonShapesToH3(polygons, acc); err != nil {
+		return nil, err
+	}
+	if c.Compact && len(acc.indexes) > 0 {
+		return h3.Compact(acc.indexes), nil
+	}
+	return acc.indexes, nil
+}
+
+// polygonShapesToH3 fills the polygon shapes as parts of a single MultiPolygon.
+func (c *Converter) polygonShapesToH3(shapes []Shape, acc *cellAccumulator) error {
+	if len(shapes) == 0 {
+		return nil
+	}
+	// the rings are not indexed, they are only walked once
+	opts := &geometry.IndexOptions{Kind: geometry.None}
+	polys := make([]*geometry.Poly, 0, len(shapes))
+	for _, shape := range shapes {
+		holes := make([][]geometry.Point, 0, len(shape.Rings)-1)
+		for _, hole := range shape.Rings[1:] {
+			holes = append(holes, toPoints(hole))
+		}
+		polys = append(polys, geometry.NewPoly(toPoints(shape.Rings[0]), holes, opts))
+	}
+	if c.FillRule == FillRuleEvenOdd {
+		indexes, _, err := c.evenOddToH3(geojson.NewMultiPolygon(polys))
+		if err != nil {
+			return err
+		}
+		return acc.add(indexes)
+	}
+	for i, poly := range polys {
+		polygon := geojson.NewPolygon(poly)
+		geoPolygon := h3.GeoPolygon{Geofence: shapes[i].Rings[0], Holes: shapes[i].Rings[1:]}
+		indexes, _, err := c.fillPolygon(polygon, geoPolygon, polygon.Center())
+		if err != nil {
+			return err
+		}
+		if err := acc.add(indexes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// geoLine is a line of lat/lng coordinates.
+type geoLine []h3.GeoCoord
+
+func (l geoLine) NumPoints() int {
+	return len(l)
+}
+
+func (l geoLine) NumSegments() int {
+	if len(l) < 2 {
+		return 0
+	}
+	return len(l) - 1
+}
+
+func (l geoLine) SegmentAt(index int) geometry.Segment {
+	return geometry.Segment{A: toPoint(l[index]), B: toPoint(l[index+1])}
+}
+
+func toPoint(coord h3.GeoCoord) geometry.Point {
+	return geometry.Point{X: coord.Longitude, Y: coord.Latitude}
+}
+
+func toPoints(coords []h3.GeoCoord) []geometry.Point {
+	points := make([]geometry.Point, 0, len(coords))
+	for _, coord := range coords {
+		points = append(points, toPoint(coord))
+	}
+	return points
+}
+
+// FromGeoJSON returns a GeoJSON object as a geometry. The shapes of all features
+// are parts of a single geometry, so with FillRuleEvenOdd the overlapping polygons
+// of different features cancel out, unlike ToH3 which converts each feature apart.
+func FromGeoJSON(o geojson.Object) Geometry {
+	return geojsonGeometry{o}
+}
+
+type geojsonGeometry struct {
+	o geojson.Object
+}
+
+func (g geojsonGeometry) Shapes() (shapes []Shape, err error) {
+	if g.o == nil {
+		return nil, fmt.Errorf("geojson.Object is nil")
+	}
+	err = forEachGeometry(g.o, func(geom geojson.Object, _ *geojson.Feature) error {
+		return geojsonShapes(geom, func(shape Shape) {
+			shapes = append(shapes, shape)
+		})
+	})
+	return
+}
+
+func geojsonShapes(o geojson.Object, fn func(shape Shape)) (err error) {
+	switch typ := o.(type) {
+	case *geojson.MultiPoint, *geojson.MultiLineString, *geojson.MultiPolygon:
+		typ.ForEach(func(geom geojson.Object) bool {
+			err = geojsonShapes(geom, fn)
+			return err == nil
+		})
+	case *geojson.Point:
+		fn(Shape{Kind: PointShape, Rings: [][]h3.GeoCoord{{toGeoCoord(typ.Base())}}})
+	case *geojson.SimplePoint:
+		fn(Shape{Kind: PointShape, Rings: [][]h3.GeoCoord{{toGeoCoord(typ.Base())}}})
+	case *geojson.LineString:
+		fn(Shape{Kind: LineShape, Rings: [][]h3.GeoCoord{seriesToGeoCoords(typ.Base())}})
+	case *geojson.Polygon:
+		rings := make([][]h3.GeoCoord, 0, 1+len(typ.Base().Holes))
+		rings = append(rings, seriesToGeoCoords(typ.Base().Exterior))
+		for _, hole := range typ.Base().Holes {
+			rings = append(rings, seriesToGeoCoords(hole))
+		}
+		fn(Shape{Kind: PolygonShape, Rings: rings})
+	case *geojson.Rect:
+		fn(Shape{Kind: PolygonShape, Rings: [][]h3.GeoCoord{seriesToGeoCoords(typ.Base())}})
+	case *geojson.Circle:
+		polygon, ok := typ.Primative().(*geojson.Polygon)
+		if !ok {
+			return fmt.Errorf("expected geojson.Polygon, got %T", typ.Primative())
+		}
+		err = geojsonShapes(polygon, fn)
+	default:
+		err = fmt.Errorf("unknown GeoJSON object")
+	}
+	return
+}
+
+// series is a series of points.
+type series interface {
+	NumPoints() int
+	PointAt(index int) geometry.Point
+}
+
+func seriesToGeoCoords(s series) []h3.GeoCoord {
+	coords := make([]h3.GeoCoord, 0, s.NumPoints())
+	for i := 0; i < s.NumPoints(); i++ {
+		coords = append(coords, toGeoCoord(s.PointAt(i)))
+	}
+	return coords
+}
+
+func toGeoCoord(point geometry.Point) h3.GeoCoord {
+	return h3.GeoCoord{
+		Latitude:  point.Y,
+		Longitude: point.X,
+	}
+}
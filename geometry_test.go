@@ -0,0 +1,209 @@
+package geojson2h3
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestGeoJSONGeometryToH3(t *testing.T) {
+	polygon := strToPoints(`
+[-73.932043, 40.731168],
+[-73.888112, 40.67702],
+[-73.812604, 40.757185],
+[-73.844867, 40.797232],
+[-73.846239, 40.764468],
+[-73.870951, 40.749381],
+[-73.87301, 40.776431],
+[-73.895662, 40.773831],
+[-73.893603, 40.758746],
+[-73.870951, 40.735331],
+[-73.891544, 40.739495],
+[-73.864087, 40.724402],
+[-73.892917, 40.708265],
+[-73.908018, 40.742617],
+[-73.932043, 40.731168]
+`)
+	hole := strToPoints(`
+[-73.87201, 40.745115],
+[-73.864717, 40.750058],
+[-73.847899, 40.738221],
+[-73.840949, 40.743815],
+[-73.87201, 40.745115]
+`)
+	line := strToPoints(`
+[-74.010794, 40.729827],
+[-73.932541, 40.67698],
+[-73.914179, 40.735812]
+`)
+	testCases := []struct {
+		name   string
+		res    int
+		object geojson.Object
+	}{
+		{
+			name:   "polygon with hole",
+			res:    9,
+			object: geojson.NewPolygon(geometry.NewPoly(polygon, [][]geometry.Point{hole}, nil)),
+		},
+		{
+			name:   "polygon fallback",
+			res:    3,
+			object: geojson.NewPolygon(geometry.NewPoly(polygon, nil, nil)),
+		},
+		{
+			name:   "line string",
+			res:    10,
+			object: geojson.NewLineString(geometry.NewLine(line, nil)),
+		},
+		{
+			name:   "multi point",
+			res:    7,
+			object: geojson.NewMultiPoint(line),
+		},
+		{
+			name:   "circle",
+			res:    7,
+			object: geojson.NewCircle(geometry.Point{X: -74.143609, Y: 40.751389}, 5000, 16),
+		},
+		{
+			name: "feature collection",
+			res:  8,
+			object: geojson.NewFeatureCollection([]geojson.Object{
+				geojson.NewFeature(geojson.NewPolygon(geometry.NewPoly(polygon, nil, nil)), ""),
+				geojson.NewFeature(geojson.NewSimplePoint(line[0]), ""),
+				geojson.NewFeature(geojson.NewMultiLineString([]*geometry.Line{
+					geometry.NewLine(line, nil),
+				}), ""),
+			}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			want, err := ToH3(tc.res, tc.object)
+			if err != nil {
+				t.Fatal(err)
+			}
+			have, err := GeometryToH3(tc.res, FromGeoJSON(tc.object))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertSameIndexes(t, want, have)
+		})
+	}
+}
+
+type testGeometry []Shape
+
+func (g testGeometry) Shapes() ([]Shape, error) {
+	return g, nil
+}
+
+func TestCustomGeometryToH3(t *testing.T) {
+	res := 7
+	point := h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}
+	indexes, err := GeometryToH3(res, testGeometry{
+		{Kind: PointShape, Rings: [][]h3.GeoCoord{{point}}},
+		{Kind: PointShape, Rings: [][]h3.GeoCoord{{point}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 1, len(indexes); want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+
+	invalid := []Geometry{
+		nil,
+		testGeometry{{Kind: PointShape}},
+		testGeometry{{Kind: LineShape, Rings: [][]h3.GeoCoord{{point}}}},
+		testGeometry{{Kind: PolygonShape, Rings: [][]h3.GeoCoord{{point, point}}}},
+		testGeometry{{Kind: ShapeKind(42), Rings: [][]h3.GeoCoord{{point}}}},
+		FromGeoJSON(nil),
+		FromGeoJSON(geojson.NewFeatureCollection([]geojson.Object{geojson.NewSimplePoint(geometry.Point{})})),
+	}
+	for i, g := range invalid {
+		if _, err := GeometryToH3(res, g); err == nil {
+			t.Fatalf("case %d: have nil, expected error", i)
+		}
+	}
+	if _, err := GeometryToH3(16, testGeometry{}); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+}
+
+func TestConverterGeometryToH3(t *testing.T) {
+	tiny := testGeometry{{Kind: PolygonShape, Rings: [][]h3.GeoCoord{{
+		{Latitude: 40.7, Longitude: -74.0},
+		{Latitude: 40.7, Longitude: -73.9999},
+		{Latitude: 40.7001, Longitude: -73.9999},
+		{Latitude: 40.7, Longitude: -74.0},
+	}}}}
+	if _, err := (&Converter{Resolution: 7, Fallback: FallbackError}).GeometryToH3(tiny); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	indexes, err := (&Converter{Resolution: 7, Fallback: FallbackEmpty}).GeometryToH3(tiny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 0, len(indexes); want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	line := testGeometry{{Kind: LineShape, Rings: [][]h3.GeoCoord{{
+		{Latitude: 40.7, Longitude: -74.0},
+		{Latitude: 40.8, Longitude: -73.9},
+	}}}}
+	if _, err := (&Converter{Resolution: 9, MaxCells: 10}).GeometryToH3(line); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	// the nested polygon shapes are combined by the fill rule
+	nested := testGeometry{
+		{Kind: PolygonShape, Rings: [][]h3.GeoCoord{{
+			{Latitude: 40.7, Longitude: -74.0},
+			{Latitude: 40.7, Longitude: -73.8},
+			{Latitude: 40.9, Longitude: -73.8},
+			{Latitude: 40.9, Longitude: -74.0},
+			{Latitude: 40.7, Longitude: -74.0},
+		}}},
+		{Kind: PolygonShape, Rings: [][]h3.GeoCoord{{
+			{Latitude: 40.75, Longitude: -73.95},
+			{Latitude: 40.75, Longitude: -73.85},
+			{Latitude: 40.85, Longitude: -73.85},
+			{Latitude: 40.85, Longitude: -73.95},
+			{Latitude: 40.75, Longitude: -73.95},
+		}}},
+	}
+	union, err := (&Converter{Resolution: 8}).GeometryToH3(nested)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evenOdd, err := (&Converter{Resolution: 8, FillRule: FillRuleEvenOdd}).GeometryToH3(nested)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evenOdd) >= len(union) {
+		t.Fatalf("have %d, want < %d", len(evenOdd), len(union))
+	}
+	// the polygons of different features are parts of the same geometry,
+	// ToH3 converts each feature apart
+	features, err := geojson.Parse(`{"type":"FeatureCollection","features":[
+{"type":"Feature","properties":{},"geometry":{"type":"Polygon","coordinates":[[[-74.0,40.7],[-73.8,40.7],[-73.8,40.9],[-74.0,40.9],[-74.0,40.7]]]}},
+{"type":"Feature","properties":{},"geometry":{"type":"Polygon","coordinates":[[[-73.95,40.75],[-73.85,40.75],[-73.85,40.85],[-73.95,40.85],[-73.95,40.75]]]}}
+]}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv := &Converter{Resolution: 8, FillRule: FillRuleEvenOdd}
+	have, err := conv.GeometryToH3(FromGeoJSON(features))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, evenOdd, have)
+	separate, err := conv.ToH3(features)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, union, separate)
+}
@@ -3,12 +3,19 @@ module github.com/mmadfox/go-geojson2h3
 go 1.18
 
 require (
+	github.com/paulmach/orb v0.11.1
+	github.com/tidwall/geojson v1.3.5
+	github.com/tidwall/gjson v1.12.1
+	github.com/uber/h3-go/v3 v3.7.1
+)
+
+require (
+	github.com/gogo/protobuf v1.3.2 // indirect
+	github.com/paulmach/protoscan v0.2.1 // indirect
 	github.com/tidwall/geoindex v1.4.4 // indirect
-	github.com/tidwall/geojson v1.3.5 // indirect
-	github.com/tidwall/gjson v1.12.1 // indirect
 	github.com/tidwall/match v1.1.1 // indirect
 	github.com/tidwall/pretty v1.2.0 // indirect
 	github.com/tidwall/rtree v1.3.1 // indirect
 	github.com/tidwall/sjson v1.2.4 // indirect
-	github.com/uber/h3-go/v3 v3.7.1 // indirect
+	go.mongodb.org/mongo-driver v1.11.4 // indirect
 )
@@ -12,33 +12,43 @@ import (
 // ToFeatureCollection converts a set of hexagons to a GeoJSON `FeatureCollection`
 // with the set outline(s). The feature's geometry type will be `Polygon`.
 func ToFeatureCollection(indexes []h3.H3Index) (*geojson.FeatureCollection, error) {
+	return toFeatureCollection(indexes, toH3Props)
+}
+
+func toFeatureCollection(indexes []h3.H3Index, props func(index h3.H3Index) string) (*geojson.FeatureCollection, error) {
 	if len(indexes) == 0 {
 		return nil, fmt.Errorf("uber h3 indexes are empty")
 	}
 	features := make([]geojson.Object, 0, len(indexes))
 	for _, index := range indexes {
-		boundary := h3.ToGeoBoundary(index)
-		points := make([]geometry.Point, 0, 6)
-		for _, b := range boundary {
-			points = append(points, geometry.Point{
-				X: b.Longitude,
-				Y: b.Latitude,
-			})
-		}
-		points = append(points, geometry.Point{
-			X: points[0].X,
-			Y: points[0].Y,
-		})
+		points := cellBoundary(index)
 		polygon := geojson.NewPolygon(
 			geometry.NewPoly(points, nil, &geometry.IndexOptions{
 				Kind: geometry.None,
 			}))
-		feature := geojson.NewFeature(polygon, toH3Props(index))
+		feature := geojson.NewFeature(polygon, props(index))
 		features = append(features, feature)
 	}
 	return geojson.NewFeatureCollection(features), nil
 }
 
+// cellBoundary returns the closed outline of the hexagon.
+func cellBoundary(index h3.H3Index) []geometry.Point {
+	boundary := h3.ToGeoBoundary(index)
+	points := make([]geometry.Point, 0, len(boundary)+1)
+	for _, b := range boundary {
+		points = append(points, geometry.Point{
+			X: b.Longitude,
+			Y: b.Latitude,
+		})
+	}
+	points = append(points, geometry.Point{
+		X: points[0].X,
+		Y: points[0].Y,
+	})
+	return points
+}
+
 func toH3Props(index h3.H3Index) string {
 	res := strconv.Itoa(h3.Resolution(index))
 	return `{"h3index":"` + h3.ToString(index) + `", "h3resolution": ` + res + `}`
@@ -0,0 +1,75 @@
+// Package h3mvt renders hexagons to Mapbox Vector Tiles.
+// It is a separate package to keep orb out of the dependencies of geojson2h3.
+package h3mvt
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/encoding/mvt"
+	orbgeojson "github.com/paulmach/orb/geojson"
+	"github.com/paulmach/orb/maptile"
+	"github.com/uber/h3-go/v3"
+)
+
+// Tile is the address of a web mercator tile.
+type Tile struct {
+	Z, X, Y uint32
+}
+
+// Marshal converts a set of hexagons to a Mapbox Vector Tile with a single layer
+// of hexagon outlines. The outlines are projected to the tile coordinates and
+// clipped by the tile bounds, the hexagons outside the tile are skipped.
+//
+// Each feature has the `h3index` and `h3resolution` properties,
+// extended with the props of the hexagon if any.
+func Marshal(indexes []h3.H3Index, tile Tile, layer string, props map[h3.H3Index]map[string]interface{}) ([]byte, error) {
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("uber h3 indexes are empty")
+	}
+	if tile.Z > 31 || tile.X >= 1<<tile.Z || tile.Y >= 1<<tile.Z {
+		return nil, fmt.Errorf("got invalid tile %d/%d/%d", tile.Z, tile.X, tile.Y)
+	}
+	mapTile := maptile.New(tile.X, tile.Y, maptile.Zoom(tile.Z))
+	bound := mapTile.Bound()
+	fc := orbgeojson.NewFeatureCollection()
+	for _, index := range indexes {
+		ring := cellRing(index, bound.Center()[0])
+		if !ring.Bound().Intersects(bound) {
+			continue
+		}
+		feature := orbgeojson.NewFeature(orb.Polygon{ring})
+		for key, value := range props[index] {
+			feature.Properties[key] = value
+		}
+		feature.Properties["h3index"] = h3.ToString(index)
+		feature.Properties["h3resolution"] = h3.Resolution(index)
+		fc.Append(feature)
+	}
+	l := mvt.NewLayer(layer, fc)
+	l.ProjectToTile(mapTile)
+	l.Clip(mvt.MapboxGLDefaultExtentBound)
+	return mvt.Marshal(mvt.Layers{l})
+}
+
+// cellRing returns the closed outline of the hexagon with the longitudes
+// unwrapped around the longitude of the tile center, so the hexagons
+// crossing the antimeridian are not stretched across the whole world.
+func cellRing(index h3.H3Index, lng float64) orb.Ring {
+	boundary := h3.ToGeoBoundary(index)
+	ring := make(orb.Ring, 0, len(boundary)+1)
+	x := lng + wrapLongitude(boundary[0].Longitude-lng)
+	for i, b := range boundary {
+		if i > 0 {
+			x += wrapLongitude(b.Longitude - boundary[i-1].Longitude)
+		}
+		ring = append(ring, orb.Point{x, b.Latitude})
+	}
+	return append(ring, ring[0])
+}
+
+// wrapLongitude returns the longitude difference in the [-180, 180) range.
+func wrapLongitude(d float64) float64 {
+	return math.Mod(math.Mod(d+180, 360)+360, 360) - 180
+}
@@ -0,0 +1,110 @@
+package h3mvt
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/encoding/mvt"
+	"github.com/paulmach/orb/maptile"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestMarshal(t *testing.T) {
+	res := 8
+	center := h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}
+	origin := h3.FromGeo(center, res)
+	indexes := h3.KRing(origin, 3)
+	far := h3.FromGeo(h3.GeoCoord{Latitude: 51.5, Longitude: -0.12}, res)
+	indexes = append(indexes, far)
+
+	mapTile := maptile.At(orb.Point{center.Longitude, center.Latitude}, 12)
+	tile := Tile{Z: uint32(mapTile.Z), X: mapTile.X, Y: mapTile.Y}
+	props := map[h3.H3Index]map[string]interface{}{
+		origin: {"count": 42},
+	}
+	data, err := Marshal(indexes, tile, "h3", props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	layers, err := mvt.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 1, len(layers); want != have {
+		t.Fatalf("have %d layers, want %d", have, want)
+	}
+	layer := layers[0]
+	if want, have := "h3", layer.Name; want != have {
+		t.Fatalf("have %s, want %s", have, want)
+	}
+	if len(layer.Features) == 0 || len(layer.Features) >= len(indexes) {
+		t.Fatalf("unexpected number of features %d", len(layer.Features))
+	}
+	found := false
+	for _, feature := range layer.Features {
+		if feature.Properties["h3index"] == h3.ToString(far) {
+			t.Fatalf("cell outside the tile is rendered")
+		}
+		if feature.Properties["h3index"] != h3.ToString(origin) {
+			continue
+		}
+		found = true
+		if feature.Properties["count"] != float64(42) {
+			t.Fatalf("have %v, want 42", feature.Properties["count"])
+		}
+		bound := feature.Geometry.Bound()
+		if bound.Min[0] < 0 || bound.Max[0] > 4096 || bound.Min[1] < 0 || bound.Max[1] > 4096 {
+			t.Fatalf("geometry %v is not in tile coordinates", bound)
+		}
+	}
+	if !found {
+		t.Fatalf("cell %s is missing", h3.ToString(origin))
+	}
+}
+
+func TestAntimeridianMarshal(t *testing.T) {
+	index := h3.FromString("865ba50b7ffffff")
+	center := h3.ToGeo(index)
+	for _, point := range []orb.Point{{center.Longitude, center.Latitude}, {179.9, center.Latitude}} {
+		mapTile := maptile.At(point, 6)
+		tile := Tile{Z: uint32(mapTile.Z), X: mapTile.X, Y: mapTile.Y}
+		data, err := Marshal([]h3.H3Index{index}, tile, "h3", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		layers, err := mvt.Unmarshal(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(layers[0].Features) != 1 {
+			t.Fatalf("have %d, want %d", len(layers[0].Features), 1)
+		}
+		// a hexagon is a few extent units wide at zoom 6
+		bound := layers[0].Features[0].Geometry.Bound()
+		if width := bound.Max[0] - bound.Min[0]; width > 100 {
+			t.Fatalf("have width %v, want the hexagon not stretched across the tile", width)
+		}
+	}
+	africa := maptile.At(orb.Point{20, 10}, 6)
+	data, err := Marshal([]h3.H3Index{index}, Tile{Z: uint32(africa.Z), X: africa.X, Y: africa.Y}, "h3", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	layers, err := mvt.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layers[0].Features) != 0 {
+		t.Fatalf("have %d, want %d", len(layers[0].Features), 0)
+	}
+}
+
+func TestInvalidMarshal(t *testing.T) {
+	if _, err := Marshal(nil, Tile{}, "h3", nil); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	index := h3.FromGeo(h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}, 8)
+	if _, err := Marshal([]h3.H3Index{index}, Tile{Z: 1, X: 2, Y: 0}, "h3", nil); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+}
@@ -0,0 +1,175 @@
+package geojson2h3
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/gjson"
+	"github.com/uber/h3-go/v3"
+)
+
+// Bin is a hexagon of the histogram with the statistics of the points within it.
+type Bin struct {
+	Index h3.H3Index
+	// Count is the number of points within the hexagon.
+	Count int
+	// Values is the number of points with a numeric property value.
+	Values int
+	Sum    float64
+	Min    float64
+	Max    float64
+}
+
+// Mean returns the mean of the property values, or zero if there are no values.
+func (b *Bin) Mean() float64 {
+	if b.Values == 0 {
+		return 0
+	}
+	return b.Sum / float64(b.Values)
+}
+
+func (b *Bin) add(value float64, ok bool) {
+	b.Count++
+	if !ok {
+		return
+	}
+	if b.Values == 0 || value < b.Min {
+		b.Min = value
+	}
+	if b.Values == 0 || value > b.Max {
+		b.Max = value
+	}
+	b.Values++
+	b.Sum += value
+}
+
+// Histogram is a set of hexagons with the number of points within each of them.
+type Histogram struct {
+	Resolution int
+	Property   string
+	bins       []*Bin
+	index      map[h3.H3Index]*Bin
+}
+
+// Bins returns the bins in the order in which the hexagons were first visited.
+func (h *Histogram) Bins() []*Bin {
+	return h.bins
+}
+
+// Bin returns the bin of the hexagon.
+func (h *Histogram) Bin(index h3.H3Index) (*Bin, bool) {
+	bin, ok := h.index[index]
+	return bin, ok
+}
+
+// Indexes returns the hexagons of the histogram.
+func (h *Histogram) Indexes() []h3.H3Index {
+	indexes := make([]h3.H3Index, 0, len(h.bins))
+	for _, bin := range h.bins {
+		indexes = append(indexes, bin.Index)
+	}
+	return indexes
+}
+
+// ToFeatureCollection converts the histogram to a GeoJSON `FeatureCollection`
+// with the hexagon outlines. The statistics of each bin are stored in the feature properties.
+func (h *Histogram) ToFeatureCollection() (*geojson.FeatureCollection, error) {
+	return toFeatureCollection(h.Indexes(), func(index h3.H3Index) string {
+		bin := h.index[index]
+		props := `"count":` + strconv.Itoa(bin.Count)
+		if h.Property != "" && bin.Values > 0 {
+			props += `,"sum":` + formatFloat(bin.Sum) +
+				`,"min":` + formatFloat(bin.Min) +
+				`,"max":` + formatFloat(bin.Max) +
+				`,"mean":` + formatFloat(bin.Mean())
+		}
+		return `{"h3index":"` + h3.ToString(index) + `", "h3resolution": ` +
+			strconv.Itoa(h.Resolution) + `, "properties":{` + props + `}}`
+	})
+}
+
+func (h *Histogram) add(index h3.H3Index, value float64, ok bool) {
+	bin, found := h.index[index]
+	if !found {
+		bin = &Bin{Index: index}
+		h.index[index] = bin
+		h.bins = append(h.bins, bin)
+	}
+	bin.add(value, ok)
+}
+
+// ToHistogram bins a GeoJSON point objects into hexagons with specified resolution.
+// If the property is not empty, the numeric values of the feature property
+// are aggregated as well.
+//
+// Known list of objects:
+//  - Point, MultiPoint, SimplePoint
+//  - GeometryCollection, Feature, FeatureCollection of the objects above
+func ToHistogram(resolution int, o geojson.Object, property string) (*Histogram, error) {
+	if o == nil {
+		return nil, fmt.Errorf("geojson.Object is nil")
+	}
+	if resolution < 0 || resolution > 15 {
+		return nil, fmt.Errorf("got invalid resolution %d. expected from 0 to 15",
+			resolution)
+	}
+	h := &Histogram{
+		Resolution: resolution,
+		Property:   property,
+		index:      make(map[h3.H3Index]*Bin),
+	}
+	err := forEachGeometry(o, func(geom geojson.Object, feature *geojson.Feature) error {
+		value, ok := float64(0), false
+		if feature != nil {
+			value, ok = featureNumber(feature, h.Property)
+		}
+		return h.bin(geom, value, ok)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *Histogram) bin(o geojson.Object, value float64, ok bool) (err error) {
+	switch typ := o.(type) {
+	case *geojson.MultiPoint:
+		typ.ForEach(func(geom geojson.Object) bool {
+			err = h.bin(geom, value, ok)
+			return err == nil
+		})
+	case *geojson.Point:
+		h.add(pointToH3(h.Resolution, typ)[0], value, ok)
+	case *geojson.SimplePoint:
+		h.add(simplePointToH3(h.Resolution, typ)[0], value, ok)
+	default:
+		err = fmt.Errorf("expected geojson.Point or geojson.MultiPoint, got %T", o)
+	}
+	return
+}
+
+// featureNumber returns the numeric value of the feature property.
+func featureNumber(feature *geojson.Feature, property string) (value float64, ok bool) {
+	if property == "" {
+		return 0, false
+	}
+	gjson.Get(feature.Members(), "properties").ForEach(func(key, val gjson.Result) bool {
+		if key.String() != property {
+			return true
+		}
+		if val.Type == gjson.Number {
+			value, ok = val.Float(), true
+		}
+		return false
+	})
+	return
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return "null"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
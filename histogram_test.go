@@ -0,0 +1,99 @@
+package geojson2h3
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/tidwall/gjson"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestFeatureCollectionToHistogram(t *testing.T) {
+	res := 7
+	o, err := geojson.Parse(`{"type":"FeatureCollection","features":[
+{"type":"Feature","properties":{"speed":10},"geometry":{"type":"Point","coordinates":[-74.143609,40.751389]}},
+{"type":"Feature","properties":{"speed":30},"geometry":{"type":"Point","coordinates":[-74.143600,40.751380]}},
+{"type":"Feature","properties":{"speed":"fast"},"geometry":{"type":"Point","coordinates":[-74.143610,40.751390]}},
+{"type":"Feature","properties":{"speed":5},"geometry":{"type":"MultiPoint","coordinates":[[-73.923951,40.547124],[-73.737928,40.75451]]}}
+]}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	histogram, err := ToHistogram(res, o, "speed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 3, len(histogram.Bins()); want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	index := h3.FromGeo(h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}, res)
+	bin, ok := histogram.Bin(index)
+	if !ok {
+		t.Fatalf("bin %s not found", h3.ToString(index))
+	}
+	if bin.Count != 3 || bin.Values != 2 || bin.Sum != 40 || bin.Min != 10 || bin.Max != 30 || bin.Mean() != 20 {
+		t.Fatalf("unexpected bin %+v", bin)
+	}
+	if want, have := index, histogram.Indexes()[0]; want != have {
+		t.Fatalf("have %s, want %s", h3.ToString(have), h3.ToString(want))
+	}
+	featureCollection, err := histogram.ToFeatureCollection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 3, len(featureCollection.Base()); want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	props := gjson.Get(featureCollection.JSON(), "features.0.properties")
+	if props.Get("count").Int() != 3 || props.Get("mean").Float() != 20 {
+		t.Fatalf("unexpected properties %s", props.Raw)
+	}
+}
+
+func TestPointsToHistogram(t *testing.T) {
+	res := 7
+	points := []geometry.Point{
+		{X: -74.143609, Y: 40.751389},
+		{X: -74.143609, Y: 40.751389},
+		{X: -73.923951, Y: 40.547124},
+	}
+	collection := geojson.NewGeometryCollection([]geojson.Object{
+		geojson.NewMultiPoint(points),
+		geojson.NewSimplePoint(points[0]),
+	})
+	histogram, err := ToHistogram(res, collection, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, len(histogram.Bins()); want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	if want, have := 3, histogram.Bins()[0].Count; want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	if want, have := float64(0), histogram.Bins()[0].Mean(); want != have {
+		t.Fatalf("have %f, want %f", have, want)
+	}
+}
+
+func TestInvalidToHistogram(t *testing.T) {
+	if _, err := ToHistogram(7, nil, ""); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	point := geojson.NewPoint(geometry.Point{X: -74.143609, Y: 40.751389})
+	if _, err := ToHistogram(16, point, ""); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	lineString := geojson.NewLineString(geometry.NewLine(strToPoints(`
+[-73.992074, 40.719831],
+[-73.992026, 40.719949]
+`), nil))
+	if _, err := ToHistogram(7, lineString, ""); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	fc := geojson.NewFeatureCollection([]geojson.Object{point})
+	if _, err := ToHistogram(7, fc, ""); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+}
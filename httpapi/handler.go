@@ -0,0 +1,183 @@
+// Package httpapi exposes the geojson2h3 conversions as an HTTP service.
+//
+// Endpoints:
+//  - POST /polyfill?resolution=<0..15> with a GeoJSON body,
+//    returns `{"cells":["<h3index>",...]}`
+//  - POST /cells-to-geojson with a `{"cells":["<h3index>",...]}` body,
+//    returns a GeoJSON FeatureCollection with the cell outlines
+//
+// Errors are returned as `{"error":"<message>"}`.
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/mmadfox/go-geojson2h3"
+	"github.com/tidwall/geojson"
+	"github.com/uber/h3-go/v3"
+)
+
+const (
+	DefaultMaxBodyBytes = 1 << 20
+	DefaultMaxCells     = 100000
+)
+
+// Options configures the handler.
+type Options struct {
+	// MaxBodyBytes limits the size of a request body.
+	// DefaultMaxBodyBytes is used when zero.
+	MaxBodyBytes int64
+	// MaxCells limits the number of cells of a polyfill result
+	// and of a cells-to-geojson request.
+	// DefaultMaxCells is used when zero.
+	MaxCells int
+}
+
+// Cells is the JSON representation of a set of cells.
+type Cells struct {
+	Cells []string `json:"cells"`
+}
+
+type handler struct {
+	opts Options
+	mux  *http.ServeMux
+}
+
+// New returns a new HTTP handler serving the conversions.
+func New(opts Options) http.Handler {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if opts.MaxCells <= 0 {
+		opts.MaxCells = DefaultMaxCells
+	}
+	h := &handler{opts: opts, mux: http.NewServeMux()}
+	h.mux.HandleFunc("/polyfill", h.polyfill)
+	h.mux.HandleFunc("/cells-to-geojson", h.cellsToGeoJSON)
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *handler) polyfill(w http.ResponseWriter, r *http.Request) {
+	if !allowPost(w, r) {
+		return
+	}
+	resolution, err := strconv.Atoi(r.URL.Query().Get("resolution"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("got invalid resolution %q", r.URL.Query().Get("resolution")))
+		return
+	}
+	body, err := h.readBody(r)
+	if err != nil {
+		writeBodyError(w, err)
+		return
+	}
+	o, err := geojson.Parse(string(body), nil)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// the converter estimates the cells before the polyfill,
+	// so a small body with a huge polygon is rejected early
+	conv := &geojson2h3.Converter{Resolution: resolution, MaxCells: h.opts.MaxCells}
+	indexes, err := conv.ToH3(o)
+	if errors.Is(err, geojson2h3.ErrMaxCells) {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	cells := Cells{Cells: make([]string, 0, len(indexes))}
+	for _, index := range indexes {
+		cells.Cells = append(cells.Cells, h3.ToString(index))
+	}
+	writeJSON(w, http.StatusOK, cells)
+}
+
+func (h *handler) cellsToGeoJSON(w http.ResponseWriter, r *http.Request) {
+	if !allowPost(w, r) {
+		return
+	}
+	body, err := h.readBody(r)
+	if err != nil {
+		writeBodyError(w, err)
+		return
+	}
+	var cells Cells
+	if err := json.Unmarshal(body, &cells); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if len(cells.Cells) > h.opts.MaxCells {
+		writeError(w, http.StatusUnprocessableEntity,
+			fmt.Errorf("got %d cells, expected <= %d cells", len(cells.Cells), h.opts.MaxCells))
+		return
+	}
+	indexes := make([]h3.H3Index, 0, len(cells.Cells))
+	for _, cell := range cells.Cells {
+		index := h3.FromString(cell)
+		if !h3.IsValid(index) {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("got invalid cell %q", cell))
+			return
+		}
+		indexes = append(indexes, index)
+	}
+	featureCollection, err := geojson2h3.ToFeatureCollection(indexes)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/geo+json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, featureCollection.JSON())
+}
+
+var errBodyTooLarge = errors.New("request body is too large")
+
+func (h *handler) readBody(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, h.opts.MaxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > h.opts.MaxBodyBytes {
+		return nil, fmt.Errorf("%w. expected <= %d bytes", errBodyTooLarge, h.opts.MaxBodyBytes)
+	}
+	return body, nil
+}
+
+func allowPost(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodPost {
+		return true
+	}
+	w.Header().Set("Allow", http.MethodPost)
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+	return false
+}
+
+func writeBodyError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errBodyTooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+	writeError(w, http.StatusBadRequest, err)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,189 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+const polygon = `{"type":"Polygon","coordinates":[[[-73.901303,40.756892],[-73.893924,40.743755],[-73.871476,40.756278],[-73.863378,40.764175],[-73.871444,40.768467],[-73.879852,40.760014],[-73.885515,40.764045],[-73.891522,40.761054],[-73.901303,40.756892]]]}`
+
+func TestPolyfill(t *testing.T) {
+	srv := httptest.NewServer(New(Options{}))
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL+"/polyfill?resolution=9", "application/geo+json", strings.NewReader(polygon))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if want, have := http.StatusOK, resp.StatusCode; want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	var cells Cells
+	if err := json.NewDecoder(resp.Body).Decode(&cells); err != nil {
+		t.Fatal(err)
+	}
+	if len(cells.Cells) == 0 {
+		t.Fatalf("have no cells")
+	}
+
+	body, err := json.Marshal(cells)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = http.Post(srv.URL+"/cells-to-geojson", "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if want, have := http.StatusOK, resp.StatusCode; want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	var fc json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&fc); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int64(len(cells.Cells)), gjson.GetBytes(fc, "features.#").Int(); want != have {
+		t.Fatalf("have %d features, want %d", have, want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	handler := New(Options{MaxBodyBytes: 1024, MaxCells: 10})
+	testCases := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{
+			name:   "method not allowed",
+			method: http.MethodGet,
+			target: "/polyfill?resolution=9",
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "invalid resolution",
+			method: http.MethodPost,
+			target: "/polyfill?resolution=abc",
+			body:   polygon,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "resolution out of range",
+			method: http.MethodPost,
+			target: "/polyfill?resolution=16",
+			body:   polygon,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid geojson",
+			method: http.MethodPost,
+			target: "/polyfill?resolution=9",
+			body:   `{"type":"Polygon"`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "too many cells",
+			method: http.MethodPost,
+			target: "/polyfill?resolution=10",
+			body:   polygon,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "body too large",
+			method: http.MethodPost,
+			target: "/polyfill?resolution=9",
+			body:   strings.Repeat(" ", 1025) + polygon,
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "invalid cell",
+			method: http.MethodPost,
+			target: "/cells-to-geojson",
+			body:   `{"cells":["abc"]}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "empty cells",
+			method: http.MethodPost,
+			target: "/cells-to-geojson",
+			body:   `{"cells":[]}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid json",
+			method: http.MethodPost,
+			target: "/cells-to-geojson",
+			body:   `{"cells":`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "too many input cells",
+			method: http.MethodPost,
+			target: "/cells-to-geojson",
+			body:   `{"cells":["` + strings.Repeat(`8928308280fffff","`, 10) + `8928308280fffff"]}`,
+			status: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if want, have := tc.status, rec.Code; want != have {
+				t.Fatalf("have %d, want %d: %s", have, want, rec.Body.String())
+			}
+			if !gjson.Get(rec.Body.String(), "error").Exists() {
+				t.Fatalf("have %s, expected error", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestPolyfillHugePolygon(t *testing.T) {
+	handler := New(Options{})
+	continent := `{"type":"Polygon","coordinates":[[[-120,30],[-80,30],[-80,50],[-120,50],[-120,30]]]}`
+	req := httptest.NewRequest(http.MethodPost, "/polyfill?resolution=15", strings.NewReader(continent))
+	rec := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(rec, req)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("have no response, want the polygon rejected before the polyfill")
+	}
+	if want, have := http.StatusUnprocessableEntity, rec.Code; want != have {
+		t.Fatalf("have %d, want %d: %s", have, want, rec.Body.String())
+	}
+}
+
+func TestPolyfillLongLine(t *testing.T) {
+	handler := New(Options{})
+	line := `{"type":"LineString","coordinates":[[-90,0],[0,0.1],[89,0]]}`
+	req := httptest.NewRequest(http.MethodPost, "/polyfill?resolution=15", strings.NewReader(line))
+	rec := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(rec, req)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("have no response, want the line rejected while sampling")
+	}
+	if want, have := http.StatusUnprocessableEntity, rec.Code; want != have {
+		t.Fatalf("have %d, want %d: %s", have, want, rec.Body.String())
+	}
+}
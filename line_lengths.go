@@ -0,0 +1,209 @@
+package geojson2h3
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geo"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+// nudgeMeters is the distance used to step over a cell boundary
+// when walking along a segment.
+const nudgeMeters = 0.001
+
+// ToH3Lengths converts a GeoJSON line objects to a map of hexagons with specified resolution,
+// where each value is the length in meters of the line within the hexagon.
+//
+// Known list of objects:
+//  - LineString, MultiLineString
+//  - GeometryCollection, Feature, FeatureCollection of the objects above
+//
+// The lengths are built from the intersections of each segment with the hexagon boundaries,
+// so the sum of the values is equal to the length of the line. The segments crossing
+// the antimeridian take the short way around.
+func ToH3Lengths(resolution int, o geojson.Object) (lengths map[h3.H3Index]float64, err error) {
+	if o == nil {
+		return nil, fmt.Errorf("geojson.Object is nil")
+	}
+	if resolution < 0 || resolution > 15 {
+		return nil, fmt.Errorf("got invalid resolution %d. expected from 0 to 15",
+			resolution)
+	}
+	lengths = make(map[h3.H3Index]float64)
+	err = lineLengths(resolution, o, lengths)
+	if err != nil {
+		return nil, err
+	}
+	return lengths, nil
+}
+
+func lineLengths(resolution int, o geojson.Object, lengths map[h3.H3Index]float64) error {
+	return forEachGeometry(o, func(geom geojson.Object, _ *geojson.Feature) (err error) {
+		switch typ := geom.(type) {
+		case *geojson.MultiLineString:
+			typ.ForEach(func(geom geojson.Object) bool {
+				lineString, ok := geom.(*geojson.LineString)
+				if !ok {
+					return false
+				}
+				err = lineStringToH3Lengths(resolution, lineString, lengths)
+				return err == nil
+			})
+		case *geojson.LineString:
+			err = lineStringToH3Lengths(resolution, typ, lengths)
+		default:
+			err = fmt.Errorf("expected geojson.LineString or geojson.MultiLineString, got %T", geom)
+		}
+		return
+	})
+}
+
+func lineStringToH3Lengths(resolution int, lineString *geojson.LineString, lengths map[h3.H3Index]float64) error {
+	if lineString.Base().NumPoints() < 2 {
+		return fmt.Errorf("got %d points, expected >= 2 points",
+			lineString.Base().NumPoints())
+	}
+	for i := 0; i < lineString.Base().NumSegments(); i++ {
+		segmentToH3Lengths(resolution, lineString.Base().SegmentAt(i), lengths)
+	}
+	return nil
+}
+
+// segmentToH3Lengths adds the length of each piece of the segment
+// to the cell that contains it.
+func segmentToH3Lengths(resolution int, segment geometry.Segment, lengths map[h3.H3Index]float64) {
+	if segment.A == segment.B {
+		return
+	}
+	segment = unwrapSegment(segment)
+	walkSegment(resolution, segment, func(cell h3.H3Index, from, to float64) {
+		a, b := lerp(segment, from), lerp(segment, to)
+		lengths[cell] += geo.DistanceTo(a.Y, a.X, b.Y, b.X)
+	})
+}
+
+// walkSegment walks along the segment from cell to cell and calls fn
+// with the parameters of the segment piece within each cell.
+// The segment must be unwrapped by unwrapSegment.
+func walkSegment(resolution int, segment geometry.Segment, fn func(cell h3.H3Index, from, to float64)) {
+	cell := pointToCell(resolution, segment.A)
+	dist := distanceMeters(segment)
+	if dist == 0 {
+		fn(cell, 0, 1)
+		return
+	}
+	nudge := nudgeMeters / dist
+	t := float64(0)
+	for t < 1 {
+		exit := segmentExit(cell, segment, t+nudge/2)
+		exit = verifyExit(resolution, cell, segment, t, exit, nudge)
+		fn(cell, t, exit)
+		if exit >= 1 {
+			break
+		}
+		t = exit
+		next := t + nudge
+		if next > 1 {
+			next = 1
+		}
+		cell = pointToCell(resolution, lerp(segment, next))
+	}
+}
+
+// segmentExit returns the smallest parameter greater than t at which
+// the segment crosses the cell boundary, or 1 if the segment ends inside the cell.
+func segmentExit(cell h3.H3Index, segment geometry.Segment, t float64) float64 {
+	boundary := h3.ToGeoBoundary(cell)
+	exit := float64(1)
+	for i := 0; i < len(boundary); i++ {
+		c := boundary[i]
+		d := boundary[(i+1)%len(boundary)]
+		// the boundary is unwrapped around the segment like the segment itself
+		edge := geometry.Segment{
+			A: geometry.Point{X: segment.A.X + wrapLongitude(c.Longitude-segment.A.X), Y: c.Latitude},
+			B: geometry.Point{X: segment.A.X + wrapLongitude(d.Longitude-segment.A.X), Y: d.Latitude},
+		}
+		s, ok := segmentIntersection(segment, edge)
+		if ok && s > t && s < exit {
+			exit = s
+		}
+	}
+	return exit
+}
+
+// verifyExit checks that the piece of the segment between t and exit stays within the cell.
+// The boundary returned by h3.ToGeoBoundary is only an approximation of the cell shape
+// in lat/lng space, so the walk may cut a corner of a neighbour cell. In that case
+// the exit is moved back to the first change of the cell found by bisection.
+func verifyExit(resolution int, cell h3.H3Index, segment geometry.Segment, t, exit, nudge float64) float64 {
+	const samples = 8
+	for i := 1; i <= samples; i++ {
+		probe := t + (exit-t)*float64(i)/samples - nudge
+		if probe <= t {
+			continue
+		}
+		if pointToCell(resolution, lerp(segment, probe)) == cell {
+			continue
+		}
+		lo, hi := t, probe
+		for hi-lo > nudge {
+			mid := (lo + hi) / 2
+			if pointToCell(resolution, lerp(segment, mid)) == cell {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		return hi
+	}
+	return exit
+}
+
+// segmentIntersection returns the parameter along a at which
+// the segments a and b intersect.
+func segmentIntersection(a, b geometry.Segment) (float64, bool) {
+	rx, ry := a.B.X-a.A.X, a.B.Y-a.A.Y
+	sx, sy := b.B.X-b.A.X, b.B.Y-b.A.Y
+	denom := rx*sy - ry*sx
+	if denom == 0 {
+		return 0, false
+	}
+	qx, qy := b.A.X-a.A.X, b.A.Y-a.A.Y
+	t := (qx*sy - qy*sx) / denom
+	u := (qx*ry - qy*rx) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return 0, false
+	}
+	return t, true
+}
+
+// unwrapSegment moves the end of the segment by 360 degrees of longitude
+// if needed, so the segment crossing the antimeridian takes the short way around.
+// The longitudes of the unwrapped segment may be out of the [-180, 180] range,
+// which h3.FromGeo and geo.DistanceTo accept.
+func unwrapSegment(s geometry.Segment) geometry.Segment {
+	s.B.X = s.A.X + wrapLongitude(s.B.X-s.A.X)
+	return s
+}
+
+// wrapLongitude returns the longitude difference in the [-180, 180) range.
+func wrapLongitude(d float64) float64 {
+	return math.Mod(math.Mod(d+180, 360)+360, 360) - 180
+}
+
+func lerp(s geometry.Segment, t float64) geometry.Point {
+	return geometry.Point{
+		X: s.A.X + (s.B.X-s.A.X)*t,
+		Y: s.A.Y + (s.B.Y-s.A.Y)*t,
+	}
+}
+
+func pointToCell(resolution int, point geometry.Point) h3.H3Index {
+	return h3.FromGeo(h3.GeoCoord{
+		Latitude:  point.Y,
+		Longitude: point.X,
+	}, resolution)
+}
@@ -0,0 +1,134 @@
+package geojson2h3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geo"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestLineStringToH3Lengths(t *testing.T) {
+	points := strToPoints(`
+[-74.010794, 40.729827],
+[-73.932541, 40.67698],
+[-73.914179, 40.735812],
+[-73.927221, 40.717725],
+[-73.938375, 40.742186],
+[-73.937689, 40.725663],
+[-73.949015, 40.734771],
+[-73.942494, 40.705361],
+[-73.955879, 40.716424],
+[-73.96017, 40.740885],
+[-73.995349, 40.745178]
+`)
+	line := geometry.NewLine(points, nil)
+	total := float64(0)
+	for i := 0; i < line.NumSegments(); i++ {
+		total += distanceMeters(line.SegmentAt(i))
+	}
+	for res := 0; res <= 11; res++ {
+		lengths, err := ToH3Lengths(res, geojson.NewLineString(line))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum := float64(0)
+		for index, length := range lengths {
+			if h3.Resolution(index) != res {
+				t.Fatalf("resolution: %d, have cell with resolution %d", res, h3.Resolution(index))
+			}
+			if length < 0 {
+				t.Fatalf("resolution: %d, have negative length %f", res, length)
+			}
+			sum += length
+		}
+		if math.Abs(sum-total)/total > 0.001 {
+			t.Fatalf("resolution: %d, have %f meters, want %f meters", res, sum, total)
+		}
+		for i := 0; i < line.NumSegments(); i++ {
+			segment := line.SegmentAt(i)
+			for f := float64(0); f <= 1; f += 0.001 {
+				index := pointToCell(res, lerp(segment, f))
+				if _, ok := lengths[index]; !ok {
+					t.Fatalf("resolution: %d, cell %s is missing", res, h3.ToString(index))
+				}
+			}
+		}
+	}
+}
+
+func TestMultiLineStringToH3Lengths(t *testing.T) {
+	points := strToPoints(`
+[-73.992074, 40.719831],
+[-73.982026, 40.729949]
+`)
+	line := geometry.NewLine(points, nil)
+	multiLine := geojson.NewMultiLineString([]*geometry.Line{line, line})
+	single, err := ToH3Lengths(9, geojson.NewLineString(line))
+	if err != nil {
+		t.Fatal(err)
+	}
+	double, err := ToH3Lengths(9, geojson.NewFeature(multiLine, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := len(single), len(double); want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	for index, length := range single {
+		if math.Abs(double[index]-2*length) > 1e-6 {
+			t.Fatalf("cell %s: have %f, want %f", h3.ToString(index), double[index], 2*length)
+		}
+	}
+}
+
+func TestInvalidToH3Lengths(t *testing.T) {
+	if _, err := ToH3Lengths(7, nil); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	point := geojson.NewPoint(geometry.Point{X: -74.143609, Y: 40.751389})
+	if _, err := ToH3Lengths(7, point); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	lineString := geojson.NewLineString(geometry.NewLine(strToPoints(`[-73.992074, 40.719831]`), nil))
+	if _, err := ToH3Lengths(16, lineString); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	if _, err := ToH3Lengths(7, lineString); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+}
+
+func TestAntimeridianToH3Lengths(t *testing.T) {
+	for _, points := range [][]geometry.Point{
+		{{X: 179.9, Y: 10}, {X: -179.9, Y: 10}},
+		{{X: -179.9, Y: 10}, {X: 179.9, Y: 10.1}},
+	} {
+		line := geojson.NewLineString(geometry.NewLine(points, nil))
+		lengths, err := ToH3Lengths(9, line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := geo.DistanceTo(points[0].Y, points[0].X, points[1].Y, points[1].X)
+		sum := float64(0)
+		for _, length := range lengths {
+			sum += length
+		}
+		if math.Abs(sum-want)/want > 0.001 {
+			t.Fatalf("have %f meters, want %f meters", sum, want)
+		}
+		paths, err := ToH3Path(9, line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := paths[0]
+		if len(path) != len(lengths) {
+			t.Fatalf("have %d, want %d", len(path), len(lengths))
+		}
+		if last := path[len(path)-1]; last.Distance > want {
+			t.Fatalf("have %f meters, want <= %f meters", last.Distance, want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package geojson2h3
+
+import (
+	"fmt"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geo"
+	"github.com/uber/h3-go/v3"
+)
+
+// PathStep is an entry of a line into a hexagon.
+type PathStep struct {
+	Index h3.H3Index
+	// Segment is the index of the line segment on which the hexagon was entered.
+	Segment int
+	// Distance is the distance in meters along the line to the entry point.
+	Distance float64
+}
+
+// ToH3Path converts a GeoJSON line objects to ordered sequences of hexagons with specified resolution,
+// one sequence per LineString. The hexagons are listed in the traversal order of the line,
+// a hexagon is listed again each time the line re-enters it.
+//
+// Known list of objects:
+//  - LineString, MultiLineString
+//  - GeometryCollection, Feature, FeatureCollection of the objects above
+func ToH3Path(resolution int, o geojson.Object) (paths [][]PathStep, err error) {
+	if o == nil {
+		return nil, fmt.Errorf("geojson.Object is nil")
+	}
+	if resolution < 0 || resolution > 15 {
+		return nil, fmt.Errorf("got invalid resolution %d. expected from 0 to 15",
+			resolution)
+	}
+	err = linePaths(resolution, o, func(path []PathStep) {
+		paths = append(paths, path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+func linePaths(resolution int, o geojson.Object, fn func(path []PathStep)) error {
+	return forEachGeometry(o, func(geom geojson.Object, _ *geojson.Feature) (err error) {
+		switch typ := geom.(type) {
+		case *geojson.MultiLineString:
+			typ.ForEach(func(geom geojson.Object) bool {
+				lineString, ok := geom.(*geojson.LineString)
+				if !ok {
+					return false
+				}
+				var path []PathStep
+				path, err = lineStringToH3Path(resolution, lineString)
+				if err != nil {
+					return false
+				}
+				fn(path)
+				return true
+			})
+		case *geojson.LineString:
+			var path []PathStep
+			path, err = lineStringToH3Path(resolution, typ)
+			if err == nil {
+				fn(path)
+			}
+		default:
+			err = fmt.Errorf("expected geojson.LineString or geojson.MultiLineString, got %T", geom)
+		}
+		return
+	})
+}
+
+func lineStringToH3Path(resolution int, lineString *geojson.LineString) ([]PathStep, error) {
+	if lineString.Base().NumPoints() < 2 {
+		return nil, fmt.Errorf("got %d points, expected >= 2 points",
+			lineString.Base().NumPoints())
+	}
+	path := make([]PathStep, 0, lineString.Base().NumSegments())
+	offset := float64(0)
+	for i := 0; i < lineString.Base().NumSegments(); i++ {
+		segment := unwrapSegment(lineString.Base().SegmentAt(i))
+		walkSegment(resolution, segment, func(cell h3.H3Index, from, _ float64) {
+			if len(path) > 0 && path[len(path)-1].Index == cell {
+				return
+			}
+			entry := lerp(segment, from)
+			path = append(path, PathStep{
+				Index:    cell,
+				Segment:  i,
+				Distance: offset + geo.DistanceTo(segment.A.Y, segment.A.X, entry.Y, entry.X),
+			})
+		})
+		offset += distanceMeters(segment)
+	}
+	return path, nil
+}
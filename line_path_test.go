@@ -0,0 +1,93 @@
+package geojson2h3
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestLineStringToH3Path(t *testing.T) {
+	res := 9
+	points := strToPoints(`
+[-74.010794, 40.729827],
+[-73.932541, 40.67698],
+[-73.914179, 40.735812],
+[-73.927221, 40.717725]
+`)
+	line := geometry.NewLine(points, nil)
+	paths, err := ToH3Path(res, geojson.NewLineString(line))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 1, len(paths); want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	path := paths[0]
+	if want, have := pointToCell(res, points[0]), path[0].Index; want != have {
+		t.Fatalf("have %s, want %s", h3.ToString(have), h3.ToString(want))
+	}
+	if want, have := pointToCell(res, points[len(points)-1]), path[len(path)-1].Index; want != have {
+		t.Fatalf("have %s, want %s", h3.ToString(have), h3.ToString(want))
+	}
+	if path[0].Distance != 0 || path[0].Segment != 0 {
+		t.Fatalf("unexpected first step %+v", path[0])
+	}
+	for i := 1; i < len(path); i++ {
+		prev, step := path[i-1], path[i]
+		if prev.Index == step.Index {
+			t.Fatalf("step %d: repeated cell %s", i, h3.ToString(step.Index))
+		}
+		if !h3.AreNeighbors(prev.Index, step.Index) {
+			t.Fatalf("step %d: cells %s and %s are not neighbors", i,
+				h3.ToString(prev.Index), h3.ToString(step.Index))
+		}
+		if step.Distance < prev.Distance || step.Segment < prev.Segment {
+			t.Fatalf("step %d: unexpected order %+v after %+v", i, step, prev)
+		}
+	}
+	if want, have := line.NumSegments()-1, path[len(path)-1].Segment; want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+}
+
+func TestMultiLineStringToH3Path(t *testing.T) {
+	res := 9
+	forward := strToPoints(`
+[-73.992074, 40.719831],
+[-73.982026, 40.729949]
+`)
+	backward := []geometry.Point{forward[1], forward[0]}
+	multiLine := geojson.NewMultiLineString([]*geometry.Line{
+		geometry.NewLine(forward, nil),
+		geometry.NewLine(backward, nil),
+	})
+	paths, err := ToH3Path(res, geojson.NewFeature(multiLine, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, len(paths); want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	if paths[0][0].Index != paths[1][len(paths[1])-1].Index {
+		t.Fatalf("expected reversed paths")
+	}
+}
+
+func TestInvalidToH3Path(t *testing.T) {
+	if _, err := ToH3Path(7, nil); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	point := geojson.NewPoint(geometry.Point{X: -74.143609, Y: 40.751389})
+	if _, err := ToH3Path(7, point); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	lineString := geojson.NewLineString(geometry.NewLine(strToPoints(`[-73.992074, 40.719831]`), nil))
+	if _, err := ToH3Path(16, lineString); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	if _, err := ToH3Path(7, lineString); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+}
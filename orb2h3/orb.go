@@ -0,0 +1,102 @@
+// Package orb2h3 adapts the github.com/paulmach/orb geometries to geojson2h3.Geometry,
+// so they are converted to hexagons without converting them to GeoJSON.
+// It is a separate package to keep orb out of the dependencies of geojson2h3.
+package orb2h3
+
+import (
+	"fmt"
+
+	"github.com/mmadfox/go-geojson2h3"
+	"github.com/paulmach/orb"
+	orbgeojson "github.com/paulmach/orb/geojson"
+	"github.com/uber/h3-go/v3"
+)
+
+// FromGeometry returns a github.com/paulmach/orb geometry as a geometry.
+func FromGeometry(g orb.Geometry) geojson2h3.Geometry {
+	return orbGeometry{g}
+}
+
+// FromFeatureCollection returns the geometries of a github.com/paulmach/orb/geojson
+// feature collection as a geometry.
+func FromFeatureCollection(fc *orbgeojson.FeatureCollection) geojson2h3.Geometry {
+	collection := make(orb.Collection, 0)
+	if fc != nil {
+		for _, feature := range fc.Features {
+			if feature == nil || feature.Geometry == nil {
+				continue
+			}
+			collection = append(collection, feature.Geometry)
+		}
+	}
+	return orbGeometry{collection}
+}
+
+type orbGeometry struct {
+	g orb.Geometry
+}
+
+func (g orbGeometry) Shapes() (shapes []geojson2h3.Shape, err error) {
+	if g.g == nil {
+		return nil, fmt.Errorf("orb.Geometry is nil")
+	}
+	err = orbShapes(g.g, func(shape geojson2h3.Shape) {
+		shapes = append(shapes, shape)
+	})
+	return
+}
+
+func orbShapes(g orb.Geometry, fn func(shape geojson2h3.Shape)) error {
+	switch typ := g.(type) {
+	case orb.Point:
+		fn(geojson2h3.Shape{Kind: geojson2h3.PointShape, Rings: [][]h3.GeoCoord{orbToGeoCoords([]orb.Point{typ})}})
+	case orb.MultiPoint:
+		for _, point := range typ {
+			fn(geojson2h3.Shape{Kind: geojson2h3.PointShape, Rings: [][]h3.GeoCoord{orbToGeoCoords([]orb.Point{point})}})
+		}
+	case orb.LineString:
+		fn(geojson2h3.Shape{Kind: geojson2h3.LineShape, Rings: [][]h3.GeoCoord{orbToGeoCoords(typ)}})
+	case orb.MultiLineString:
+		for _, line := range typ {
+			fn(geojson2h3.Shape{Kind: geojson2h3.LineShape, Rings: [][]h3.GeoCoord{orbToGeoCoords(line)}})
+		}
+	case orb.Ring:
+		fn(geojson2h3.Shape{Kind: geojson2h3.PolygonShape, Rings: [][]h3.GeoCoord{orbToGeoCoords(typ)}})
+	case orb.Polygon:
+		fn(orbPolygonShape(typ))
+	case orb.MultiPolygon:
+		for _, polygon := range typ {
+			fn(orbPolygonShape(polygon))
+		}
+	case orb.Bound:
+		fn(orbPolygonShape(typ.ToPolygon()))
+	case orb.Collection:
+		for _, geom := range typ {
+			if err := orbShapes(geom, fn); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unknown orb.Geometry %T", g)
+	}
+	return nil
+}
+
+func orbPolygonShape(polygon orb.Polygon) geojson2h3.Shape {
+	rings := make([][]h3.GeoCoord, 0, len(polygon))
+	for _, ring := range polygon {
+		rings = append(rings, orbToGeoCoords(ring))
+	}
+	return geojson2h3.Shape{Kind: geojson2h3.PolygonShape, Rings: rings}
+}
+
+func orbToGeoCoords(points []orb.Point) []h3.GeoCoord {
+	coords := make([]h3.GeoCoord, 0, len(points))
+	for _, point := range points {
+		coords = append(coords, h3.GeoCoord{
+			Latitude:  point.Lat(),
+			Longitude: point.Lon(),
+		})
+	}
+	return coords
+}
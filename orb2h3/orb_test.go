@@ -0,0 +1,123 @@
+package orb2h3
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/mmadfox/go-geojson2h3"
+	"github.com/paulmach/orb"
+	orbgeojson "github.com/paulmach/orb/geojson"
+	"github.com/tidwall/geojson"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestOrbGeometryToH3(t *testing.T) {
+	testCases := []struct {
+		name string
+		res  int
+		json string
+		orb  orb.Geometry
+	}{
+		{
+			name: "point",
+			res:  7,
+			json: `{"type":"Point","coordinates":[-74.143609,40.751389]}`,
+			orb:  orb.Point{-74.143609, 40.751389},
+		},
+		{
+			name: "multi point",
+			res:  7,
+			json: `{"type":"MultiPoint","coordinates":[[-74.143609,40.751389],[-73.923951,40.547124]]}`,
+			orb:  orb.MultiPoint{{-74.143609, 40.751389}, {-73.923951, 40.547124}},
+		},
+		{
+			name: "line string",
+			res:  9,
+			json: `{"type":"MultiLineString","coordinates":[[[-74.010794,40.729827],[-73.932541,40.67698]]]}`,
+			orb:  orb.MultiLineString{{{-74.010794, 40.729827}, {-73.932541, 40.67698}}},
+		},
+		{
+			name: "polygon",
+			res:  9,
+			json: `{"type":"MultiPolygon","coordinates":[[[[-74.0,40.7],[-73.9,40.7],[-73.9,40.8],[-74.0,40.7]]]]}`,
+			orb:  orb.MultiPolygon{{{{-74.0, 40.7}, {-73.9, 40.7}, {-73.9, 40.8}, {-74.0, 40.7}}}},
+		},
+		{
+			name: "bound",
+			res:  8,
+			json: `{"type":"Polygon","coordinates":[[[-74.0,40.7],[-73.9,40.7],[-73.9,40.8],[-74.0,40.8],[-74.0,40.7]]]}`,
+			orb:  orb.Bound{Min: orb.Point{-74.0, 40.7}, Max: orb.Point{-73.9, 40.8}},
+		},
+		{
+			name: "collection",
+			res:  8,
+			json: `{"type":"GeometryCollection","geometries":[{"type":"Polygon","coordinates":[[[-74.0,40.7],[-73.9,40.7],[-73.9,40.8],[-74.0,40.7]]]},{"type":"LineString","coordinates":[[-74.010794,40.729827],[-73.932541,40.67698]]}]}`,
+			orb: orb.Collection{
+				orb.Ring{{-74.0, 40.7}, {-73.9, 40.7}, {-73.9, 40.8}, {-74.0, 40.7}},
+				orb.LineString{{-74.010794, 40.729827}, {-73.932541, 40.67698}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o, err := geojson.Parse(tc.json, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := geojson2h3.ToH3(tc.res, o)
+			if err != nil {
+				t.Fatal(err)
+			}
+			have, err := geojson2h3.GeometryToH3(tc.res, FromGeometry(tc.orb))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertSameIndexes(t, want, have)
+		})
+	}
+}
+
+func TestOrbFeatureCollectionToH3(t *testing.T) {
+	data := `{"type":"FeatureCollection","features":[
+{"type":"Feature","properties":{},"geometry":{"type":"Point","coordinates":[-74.143609,40.751389]}},
+{"type":"Feature","properties":{},"geometry":{"type":"Polygon","coordinates":[[[-74.0,40.7],[-73.9,40.7],[-73.9,40.8],[-74.0,40.7]]]}}
+]}`
+	fc := orbgeojson.NewFeatureCollection()
+	if err := json.Unmarshal([]byte(data), fc); err != nil {
+		t.Fatal(err)
+	}
+	o, err := geojson.Parse(data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := geojson2h3.ToH3(8, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := geojson2h3.GeometryToH3(8, FromFeatureCollection(fc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, want, have)
+
+	if _, err := geojson2h3.GeometryToH3(8, FromGeometry(nil)); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+}
+
+func assertSameIndexes(t *testing.T, want, have []h3.H3Index) {
+	t.Helper()
+	if len(want) != len(have) {
+		t.Fatalf("have %d indexes, want %d", len(have), len(want))
+	}
+	want = append([]h3.H3Index(nil), want...)
+	have = append([]h3.H3Index(nil), have...)
+	sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+	sort.Slice(have, func(i, j int) bool { return have[i] < have[j] })
+	for i := range want {
+		if want[i] != have[i] {
+			t.Fatalf("have %s, want %s", h3.ToString(have[i]), h3.ToString(want[i]))
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package geojson2h3
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+// toPolygons returns the outlines of a set of hexagons as polygons with holes.
+// Only the hexagon edges facing a hexagon outside the set are traced, so
+// adjacent hexagons are merged into a single polygon.
+//
+// The exterior rings are counter-clockwise, the holes are clockwise.
+func toPolygons(indexes []h3.H3Index) []*geometry.Poly {
+	set := make(map[h3.H3Index]struct{}, len(indexes))
+	for _, index := range indexes {
+		set[index] = struct{}{}
+	}
+	edges := make(map[vertexKey][][]geometry.Point)
+	for index := range set {
+		for _, edge := range h3.ToUnidirectionalEdges(index) {
+			if _, ok := set[h3.DestinationFromUnidirectionalEdge(edge)]; ok {
+				continue
+			}
+			boundary := h3.UnidirectionalEdgeBoundary(edge)
+			points := make([]geometry.Point, 0, len(boundary))
+			for _, b := range boundary {
+				points = append(points, geometry.Point{X: b.Longitude, Y: b.Latitude})
+			}
+			key := toVertexKey(points[0])
+			edges[key] = append(edges[key], points)
+		}
+	}
+
+	keys := make([]vertexKey, 0, len(edges))
+	for key := range edges {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].x != keys[j].x {
+			return keys[i].x < keys[j].x
+		}
+		return keys[i].y < keys[j].y
+	})
+
+	exteriors := make([][]geometry.Point, 0)
+	holes := make([][]geometry.Point, 0)
+	for _, start := range keys {
+		for len(edges[start]) > 0 {
+			ring := traceRing(edges, start)
+			if len(ring) < 4 {
+				continue
+			}
+			if ringArea(ring) >= 0 {
+				exteriors = append(exteriors, ring)
+			} else {
+				holes = append(holes, ring)
+			}
+		}
+	}
+
+	exteriorHoles := make([][][]geometry.Point, len(exteriors))
+	for _, hole := range holes {
+		owner, ownerArea := -1, math.Inf(1)
+		for i, exterior := range exteriors {
+			area := ringArea(exterior)
+			if area < ownerArea && ringContains(exterior, hole[0]) {
+				owner, ownerArea = i, area
+			}
+		}
+		if owner >= 0 {
+			exteriorHoles[owner] = append(exteriorHoles[owner], hole)
+		}
+	}
+	polygons := make([]*geometry.Poly, 0, len(exteriors))
+	for i, exterior := range exteriors {
+		polygons = append(polygons, geometry.NewPoly(exterior, exteriorHoles[i], &geometry.IndexOptions{
+			Kind: geometry.None,
+		}))
+	}
+	return polygons
+}
+
+// traceRing follows the edges from the start vertex until the ring is closed.
+// The traced edges are removed from the map.
+func traceRing(edges map[vertexKey][][]geometry.Point, start vertexKey) []geometry.Point {
+	ring := make([]geometry.Point, 0)
+	key := start
+	for {
+		candidates := edges[key]
+		if len(candidates) == 0 {
+			return nil
+		}
+		edge := candidates[len(candidates)-1]
+		edges[key] = candidates[:len(candidates)-1]
+		ring = append(ring, edge[:len(edge)-1]...)
+		key = toVertexKey(edge[len(edge)-1])
+		if key == start {
+			break
+		}
+	}
+	return append(ring, ring[0])
+}
+
+// ringContains reports whether the point is inside the ring.
+func ringContains(ring []geometry.Point, point geometry.Point) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Y > point.Y) != (b.Y > point.Y) &&
+			point.X < (b.X-a.X)*(point.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// vertexKey identifies a hexagon vertex, the coordinates are rounded
+// so that the vertices shared by adjacent hexagons are equal.
+type vertexKey struct {
+	x, y int64
+}
+
+func toVertexKey(point geometry.Point) vertexKey {
+	const precision = 1e9
+	return vertexKey{
+		x: int64(math.Round(point.X * precision)),
+		y: int64(math.Round(point.Y * precision)),
+	}
+}
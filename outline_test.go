@@ -0,0 +1,52 @@
+package geojson2h3
+
+import (
+	"testing"
+
+	"github.com/uber/h3-go/v3"
+)
+
+func TestToPolygons(t *testing.T) {
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}, 8)
+	ring, err := h3.HexRing(origin, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	far := h3.FromGeo(h3.GeoCoord{Latitude: 40.547124, Longitude: -73.923951}, 8)
+	pentagon := h3.GetPentagonIndexes(8)[0]
+
+	testCases := []struct {
+		name     string
+		indexes  []h3.H3Index
+		polygons int
+		holes    int
+		points   int
+	}{
+		{name: "single cell", indexes: []h3.H3Index{origin}, polygons: 1, points: 7},
+		{name: "k-ring", indexes: h3.KRing(origin, 1), polygons: 1, points: 19},
+		{name: "ring with hole", indexes: ring, polygons: 1, holes: 1, points: 19},
+		{name: "disjoint cells", indexes: []h3.H3Index{origin, far}, polygons: 2, points: 7},
+		{name: "pentagon", indexes: []h3.H3Index{pentagon}, polygons: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			polygons := toPolygons(tc.indexes)
+			if want, have := tc.polygons, len(polygons); want != have {
+				t.Fatalf("have %d polygons, want %d", have, want)
+			}
+			holes := 0
+			for _, polygon := range polygons {
+				holes += len(polygon.Holes)
+				if polygon.Exterior.Clockwise() {
+					t.Fatalf("expected counter-clockwise exterior")
+				}
+				if tc.points > 0 && polygon.Exterior.NumPoints() != tc.points {
+					t.Fatalf("have %d points, want %d", polygon.Exterior.NumPoints(), tc.points)
+				}
+			}
+			if want, have := tc.holes, holes; want != have {
+				t.Fatalf("have %d holes, want %d", have, want)
+			}
+		})
+	}
+}
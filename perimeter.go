@@ -0,0 +1,115 @@
+package geojson2h3
+
+import (
+	"fmt"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+// Perimeter is the hexagons along the outline of a set of hexagons.
+// A hexagon facing both the outside and a hole of the set is in both lists.
+type Perimeter struct {
+	// Outer hexagons facing the outside of the set, sorted.
+	Outer []h3.H3Index
+	// Inner hexagons facing the holes of the set, sorted.
+	Inner []h3.H3Index
+}
+
+// Indexes returns the outer and inner hexagons, sorted.
+func (p Perimeter) Indexes() []h3.H3Index {
+	indexes := make([]h3.H3Index, 0, len(p.Outer)+len(p.Inner))
+	indexes = append(indexes, p.Outer...)
+	indexes = append(indexes, p.Inner...)
+	return sortCells(indexes)
+}
+
+// ToPerimeter returns the hexagons of a set with the same resolution
+// which have at least one neighbour outside the set.
+func ToPerimeter(indexes []h3.H3Index) (Perimeter, error) {
+	set, err := cellSet(indexes)
+	if err != nil {
+		return Perimeter{}, err
+	}
+	return toPerimeter(set), nil
+}
+
+// ToH3Perimeter converts a GeoJSON objects to the perimeter hexagons of each polygon,
+// Rect and Circle, like ToH3 with Perimeter enabled, keeping the outer and inner
+// hexagons apart. The other hexagons, e.g. of the points, lines and fallbacks,
+// are outer. Compact is ignored.
+func (c *Converter) ToH3Perimeter(o geojson.Object) (Perimeter, error) {
+	if o == nil {
+		return Perimeter{}, fmt.Errorf("geojson.Object is nil")
+	}
+	conv := *c
+	conv.Perimeter = true
+	if err := conv.validate(); err != nil {
+		return Perimeter{}, err
+	}
+	indexes, perimeter, err := conv.convert(o)
+	if err != nil {
+		return Perimeter{}, err
+	}
+	if conv.MaxCells > 0 && len(indexes) > conv.MaxCells {
+		return Perimeter{}, fmt.Errorf("%w. got %d hexagons, expected <= %d hexagons", ErrMaxCells,
+			len(indexes), conv.MaxCells)
+	}
+	perimeter.Outer = sortCells(perimeter.Outer)
+	perimeter.Inner = sortCells(perimeter.Inner)
+	visits := make(map[h3.H3Index]struct{}, len(perimeter.Outer)+len(perimeter.Inner))
+	for _, index := range perimeter.Indexes() {
+		visits[index] = struct{}{}
+	}
+	rest := make([]h3.H3Index, 0)
+	for _, index := range indexes {
+		if _, ok := visits[index]; !ok {
+			rest = append(rest, index)
+		}
+	}
+	if len(rest) > 0 {
+		perimeter.Outer = sortCells(append(perimeter.Outer, rest...))
+	}
+	return perimeter, nil
+}
+
+// add appends the hexagons of the other perimeter.
+func (p *Perimeter) add(other Perimeter) {
+	p.Outer = append(p.Outer, other.Outer...)
+	p.Inner = append(p.Inner, other.Inner...)
+}
+
+func toPerimeter(set map[h3.H3Index]struct{}) Perimeter {
+	cells := setToCells(set)
+	// the outer edges start at the vertices of the exterior rings
+	exterior := make(map[vertexKey]struct{})
+	for _, polygon := range toPolygons(cells) {
+		for i := 0; i < polygon.Exterior.NumPoints(); i++ {
+			exterior[toVertexKey(polygon.Exterior.PointAt(i))] = struct{}{}
+		}
+	}
+	var perimeter Perimeter
+	for _, index := range cells {
+		outer, inner := false, false
+		for _, edge := range h3.ToUnidirectionalEdges(index) {
+			if _, ok := set[h3.DestinationFromUnidirectionalEdge(edge)]; ok {
+				continue
+			}
+			boundary := h3.UnidirectionalEdgeBoundary(edge)
+			key := toVertexKey(geometry.Point{X: boundary[0].Longitude, Y: boundary[0].Latitude})
+			if _, ok := exterior[key]; ok {
+				outer = true
+			} else {
+				inner = true
+			}
+		}
+		if outer {
+			perimeter.Outer = append(perimeter.Outer, index)
+		}
+		if inner {
+			perimeter.Inner = append(perimeter.Inner, index)
+		}
+	}
+	return perimeter
+}
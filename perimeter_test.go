@@ -0,0 +1,152 @@
+package geojson2h3
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geo"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestToPerimeter(t *testing.T) {
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 9)
+	perimeter, err := ToPerimeter(h3.KRing(origin, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ring, err := h3.HexRing(origin, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, ring, perimeter.Outer)
+	if len(perimeter.Inner) != 0 {
+		t.Fatalf("have %d, want %d", len(perimeter.Inner), 0)
+	}
+
+	// a disk of radius 4 with a hole of radius 1
+	indexes := make([]h3.H3Index, 0)
+	for k := 2; k <= 4; k++ {
+		ring, err := h3.HexRing(origin, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		indexes = append(indexes, ring...)
+	}
+	perimeter, err = ToPerimeter(indexes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outer, err := h3.HexRing(origin, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner, err := h3.HexRing(origin, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, outer, perimeter.Outer)
+	assertSameIndexes(t, inner, perimeter.Inner)
+	assertSameIndexes(t, append(append([]h3.H3Index{}, outer...), inner...), perimeter.Indexes())
+}
+
+func TestPerimeterConverterToH3(t *testing.T) {
+	exterior := strToPoints(`
+[-74.0, 40.7],
+[-73.9, 40.7],
+[-73.9, 40.8],
+[-74.0, 40.8],
+[-74.0, 40.7]
+`)
+	hole := strToPoints(`
+[-73.97, 40.73],
+[-73.93, 40.73],
+[-73.93, 40.77],
+[-73.97, 40.77],
+[-73.97, 40.73]
+`)
+	polygon := geojson.NewPolygon(geometry.NewPoly(exterior, [][]geometry.Point{hole}, nil))
+	fill, err := ToH3(9, polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := (&Converter{Resolution: 9, Perimeter: true}).ToH3(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	perimeter, err := ToPerimeter(fill)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(perimeter.Outer) == 0 || len(perimeter.Inner) == 0 {
+		t.Fatalf("have %d outer and %d inner, want > 0", len(perimeter.Outer), len(perimeter.Inner))
+	}
+	assertSameIndexes(t, perimeter.Indexes(), have)
+	if len(have) >= len(fill) {
+		t.Fatalf("have %d, want < %d", len(have), len(fill))
+	}
+
+	split, err := (&Converter{Resolution: 9}).ToH3Perimeter(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, perimeter.Outer, split.Outer)
+	assertSameIndexes(t, perimeter.Inner, split.Inner)
+
+	point := geojson.NewPoint(geometry.Point{X: -73.5, Y: 40.5})
+	split, err = (&Converter{Resolution: 9}).ToH3Perimeter(geojson.NewGeometryCollection([]geojson.Object{polygon, point}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := len(perimeter.Outer)+1, len(split.Outer); want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	assertSameIndexes(t, perimeter.Inner, split.Inner)
+}
+
+func TestPerimeterFallbackToH3(t *testing.T) {
+	// a ring around the center of the origin hexagon, reaching into the neighbours
+	// without their centers, so only the fallback selects the hexagons
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 7)
+	center := h3.ToGeo(origin)
+	edge := h3.EdgeLengthM(7)
+	circle := func(radius float64) []geometry.Point {
+		points := make([]geometry.Point, 0, 25)
+		for i := 0; i < 24; i++ {
+			lat, lng := geo.DestinationPoint(center.Latitude, center.Longitude, radius, float64(i*15))
+			points = append(points, geometry.Point{X: lng, Y: lat})
+		}
+		return append(points, points[0])
+	}
+	polygon := geojson.NewPolygon(geometry.NewPoly(circle(edge*1.1), [][]geometry.Point{circle(edge * 0.1)}, nil))
+	conv := &Converter{Resolution: 7, Fallback: FallbackIntersects}
+	fallback, err := conv.ToH3(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 7, len(fallback); want != have {
+		t.Fatalf("have %d, want %d", have, want)
+	}
+	conv.Perimeter = true
+	have, err := conv.ToH3(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ring, err := h3.HexRing(origin, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, ring, have)
+	split, err := conv.ToH3Perimeter(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, have, split.Outer)
+}
+
+func TestInvalidToPerimeter(t *testing.T) {
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 9)
+	if _, err := ToPerimeter([]h3.H3Index{origin, h3.ToParent(origin, 8)}); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
@@ -0,0 +1,197 @@
+package geojson2h3
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+// ToH3Coverage converts a GeoJSON polygon objects to a map of hexagons with specified resolution,
+// where each value is the fraction (0..1) of the hexagon area covered by the polygon.
+//
+// Known list of objects:
+//  - Polygon, MultiPolygon
+//  - GeometryCollection, Feature, FeatureCollection of the objects above
+//
+// Hexagons along the polygon boundary are clipped against the polygon rings,
+// hexagons inside the polygon are fully covered.
+func ToH3Coverage(resolution int, o geojson.Object) (coverage map[h3.H3Index]float64, err error) {
+	if o == nil {
+		return nil, fmt.Errorf("geojson.Object is nil")
+	}
+	if resolution < 0 || resolution > 15 {
+		return nil, fmt.Errorf("got invalid resolution %d. expected from 0 to 15",
+			resolution)
+	}
+	coverage = make(map[h3.H3Index]float64)
+	err = polygonCoverage(resolution, o, coverage)
+	if err != nil {
+		return nil, err
+	}
+	for index, fraction := range coverage {
+		if fraction <= 0 {
+			delete(coverage, index)
+		}
+	}
+	return coverage, nil
+}
+
+func polygonCoverage(resolution int, o geojson.Object, coverage map[h3.H3Index]float64) error {
+	return forEachGeometry(o, func(geom geojson.Object, _ *geojson.Feature) (err error) {
+		switch typ := geom.(type) {
+		case *geojson.MultiPolygon:
+			typ.ForEach(func(geom geojson.Object) bool {
+				polygon, ok := geom.(*geojson.Polygon)
+				if !ok {
+					return false
+				}
+				err = polygonToH3Coverage(resolution, polygon, coverage)
+				return err == nil
+			})
+		case *geojson.Polygon:
+			err = polygonToH3Coverage(resolution, typ, coverage)
+		default:
+			err = fmt.Errorf("expected geojson.Polygon or geojson.MultiPolygon, got %T", geom)
+		}
+		return
+	})
+}
+
+func polygonToH3Coverage(resolution int, polygon *geojson.Polygon, coverage map[h3.H3Index]float64) error {
+	indexes := h3.Polyfill(toGeoPolygon(polygon), resolution)
+	rings := make([]geometry.Ring, 0, 1+len(polygon.Base().Holes))
+	rings = append(rings, polygon.Base().Exterior)
+	rings = append(rings, polygon.Base().Holes...)
+
+	edges := make(map[h3.H3Index]float64)
+	for _, ring := range rings {
+		for i := 0; i < ring.NumSegments(); i++ {
+			segmentToH3Lengths(resolution, ring.SegmentAt(i), edges)
+		}
+	}
+	// the neighbours are clipped as well, since the walk along the rings
+	// uses an approximation of the hexagon boundaries.
+	boundary := make(map[h3.H3Index]struct{}, len(edges))
+	for index := range edges {
+		for _, neighbour := range h3.KRing(index, 1) {
+			boundary[neighbour] = struct{}{}
+		}
+	}
+	for _, index := range indexes {
+		if _, ok := boundary[index]; ok {
+			continue
+		}
+		coverage[index] = 1
+	}
+	for index := range boundary {
+		fraction := cellCoverage(index, rings)
+		if fraction <= 0 {
+			continue
+		}
+		coverage[index] = math.Min(1, coverage[index]+fraction)
+	}
+	return nil
+}
+
+// cellCoverage returns the fraction of the cell area covered by
+// the exterior ring minus the holes. The longitudes are unwrapped
+// around the cell center, so the cells and rings crossing
+// the antimeridian are projected without the 360 degrees jumps.
+func cellCoverage(index h3.H3Index, rings []geometry.Ring) float64 {
+	center := h3.ToGeo(index)
+	scale := math.Cos(center.Latitude * math.Pi / 180)
+	project := func(p geometry.Point) geometry.Point {
+		return geometry.Point{
+			X: (p.X - center.Longitude) * scale,
+			Y: p.Y - center.Latitude,
+		}
+	}
+	boundary := h3.ToGeoBoundary(index)
+	cell := make([]geometry.Point, 0, len(boundary))
+	for _, b := range boundary {
+		cell = append(cell, project(geometry.Point{
+			X: center.Longitude + wrapLongitude(b.Longitude-center.Longitude),
+			Y: b.Latitude,
+		}))
+	}
+	cellArea := math.Abs(ringArea(cell))
+	if cellArea == 0 {
+		return 0
+	}
+	covered := float64(0)
+	for i, ring := range rings {
+		points := make([]geometry.Point, 0, ring.NumPoints())
+		// the first point is unwrapped around the center,
+		// the next ones around the previous point to keep the ring connected
+		prev := geometry.Point{X: center.Longitude}
+		for j := 0; j < ring.NumPoints(); j++ {
+			point := ring.PointAt(j)
+			point.X = prev.X + wrapLongitude(point.X-prev.X)
+			points = append(points, project(point))
+			prev = point
+		}
+		area := math.Abs(ringArea(clipRing(points, cell)))
+		if i == 0 {
+			covered += area
+		} else {
+			covered -= area
+		}
+	}
+	return math.Max(0, math.Min(1, covered/cellArea))
+}
+
+// clipRing clips the subject ring by the convex clip ring
+// using the Sutherland-Hodgman algorithm.
+func clipRing(subject, clip []geometry.Point) []geometry.Point {
+	sign := float64(1)
+	if ringArea(clip) < 0 {
+		sign = -1
+	}
+	output := subject
+	for i := 0; i < len(clip) && len(output) > 0; i++ {
+		a, b := clip[i], clip[(i+1)%len(clip)]
+		inside := func(p geometry.Point) bool {
+			return sign*((b.X-a.X)*(p.Y-a.Y)-(b.Y-a.Y)*(p.X-a.X)) >= 0
+		}
+		input := output
+		output = make([]geometry.Point, 0, len(input))
+		for j := 0; j < len(input); j++ {
+			cur, prev := input[j], input[(j+len(input)-1)%len(input)]
+			switch {
+			case inside(cur) && !inside(prev):
+				output = append(output, lineIntersection(prev, cur, a, b), cur)
+			case inside(cur):
+				output = append(output, cur)
+			case inside(prev):
+				output = append(output, lineIntersection(prev, cur, a, b))
+			}
+		}
+	}
+	return output
+}
+
+// lineIntersection returns the intersection point of the segment p1-p2
+// with the infinite line through a and b.
+func lineIntersection(p1, p2, a, b geometry.Point) geometry.Point {
+	dx, dy := p2.X-p1.X, p2.Y-p1.Y
+	ex, ey := b.X-a.X, b.Y-a.Y
+	denom := dx*ey - dy*ex
+	if denom == 0 {
+		return p1
+	}
+	t := ((a.X-p1.X)*ey - (a.Y-p1.Y)*ex) / denom
+	return geometry.Point{X: p1.X + dx*t, Y: p1.Y + dy*t}
+}
+
+// ringArea returns the signed area of the ring, positive for counter-clockwise rings.
+func ringArea(points []geometry.Point) float64 {
+	area := float64(0)
+	for i := 0; i < len(points); i++ {
+		a, b := points[i], points[(i+1)%len(points)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	return area / 2
+}
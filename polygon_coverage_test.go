@@ -0,0 +1,174 @@
+package geojson2h3
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestPolygonToH3Coverage(t *testing.T) {
+	points := strToPoints(`
+[-74.0, 40.7],
+[-73.9, 40.7],
+[-73.9, 40.8],
+[-74.0, 40.8],
+[-74.0, 40.7]
+`)
+	polygon := geojson.NewPolygon(geometry.NewPoly(points, nil, nil))
+	const earthRadius = 6371007.180918475
+	toRad := math.Pi / 180
+	want := earthRadius * earthRadius * 0.1 * toRad *
+		(math.Sin(40.8*toRad) - math.Sin(40.7*toRad))
+	for res := 6; res <= 9; res++ {
+		coverage, err := ToH3Coverage(res, polygon)
+		if err != nil {
+			t.Fatal(err)
+		}
+		have := float64(0)
+		for index, fraction := range coverage {
+			if fraction <= 0 || fraction > 1 {
+				t.Fatalf("resolution: %d, have fraction %f", res, fraction)
+			}
+			have += fraction * h3.CellAreaM2(index)
+		}
+		if math.Abs(have-want)/want > 0.01 {
+			t.Fatalf("resolution: %d, have %f m2, want %f m2", res, have, want)
+		}
+	}
+}
+
+func TestCellBoundaryToH3Coverage(t *testing.T) {
+	res := 8
+	index := h3.FromGeo(h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}, res)
+	points := make([]geometry.Point, 0, 7)
+	for _, b := range h3.ToGeoBoundary(index) {
+		points = append(points, geometry.Point{X: b.Longitude, Y: b.Latitude})
+	}
+	points = append(points, points[0])
+	polygon := geojson.NewPolygon(geometry.NewPoly(points, nil, nil))
+	coverage, err := ToH3Coverage(res, geojson.NewFeature(polygon, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := coverage[index]; math.Abs(have-1) > 1e-6 {
+		t.Fatalf("have %f, want 1", have)
+	}
+	for neighbour, fraction := range coverage {
+		if neighbour != index && fraction > 1e-6 {
+			t.Fatalf("cell %s: have %f, want 0", h3.ToString(neighbour), fraction)
+		}
+	}
+}
+
+func TestPolygonWithHoleToH3Coverage(t *testing.T) {
+	res := 7
+	exterior := strToPoints(`
+[-74.0, 40.7],
+[-73.9, 40.7],
+[-73.9, 40.8],
+[-74.0, 40.8],
+[-74.0, 40.7]
+`)
+	hole := strToPoints(`
+[-73.97, 40.73],
+[-73.93, 40.73],
+[-73.93, 40.77],
+[-73.97, 40.77],
+[-73.97, 40.73]
+`)
+	full, err := ToH3Coverage(res, geojson.NewPolygon(geometry.NewPoly(exterior, nil, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	holed, err := ToH3Coverage(res, geojson.NewMultiPolygon([]*geometry.Poly{
+		geometry.NewPoly(exterior, [][]geometry.Point{hole}, nil),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := func(coverage map[h3.H3Index]float64) (area float64) {
+		for index, fraction := range coverage {
+			area += fraction * h3.CellAreaM2(index)
+		}
+		return
+	}
+	if want, have := sum(full)*(1-0.16), sum(holed); math.Abs(have-want)/want > 0.01 {
+		t.Fatalf("have %f m2, want %f m2", have, want)
+	}
+	center := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, res)
+	if have := holed[center]; have > 0.5 {
+		t.Fatalf("cell %s inside the hole: have %f, want < 0.5", h3.ToString(center), have)
+	}
+}
+
+func TestAntimeridianToH3Coverage(t *testing.T) {
+	res := 6
+	index := h3.FromString("865ba50b7ffffff")
+	polygon := geojson.NewPolygon(geometry.NewPoly(strToPoints(`
+[179.5, 10.0],
+[-179.5, 10.0],
+[-179.5, 10.5],
+[179.5, 10.5],
+[179.5, 10.0]
+`), nil, nil))
+	coverage, err := ToH3Coverage(res, polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := coverage[index]; math.Abs(have-1) > 1e-6 {
+		t.Fatalf("have %f, want 1", have)
+	}
+	const earthRadius = 6371007.180918475
+	toRad := math.Pi / 180
+	want := earthRadius * earthRadius * toRad * (math.Sin(10.5*toRad) - math.Sin(10*toRad))
+	have := float64(0)
+	for index, fraction := range coverage {
+		have += fraction * h3.CellAreaM2(index)
+	}
+	if math.Abs(have-want)/want > 0.01 {
+		t.Fatalf("have %f m2, want %f m2", have, want)
+	}
+	center := h3.ToGeo(index)
+	half := geojson.NewPolygon(geometry.NewPoly([]geometry.Point{
+		{X: 179.5, Y: center.Latitude},
+		{X: -179.5, Y: center.Latitude},
+		{X: -179.5, Y: 10.5},
+		{X: 179.5, Y: 10.5},
+		{X: 179.5, Y: center.Latitude},
+	}, nil, nil))
+	coverage, err = ToH3Coverage(res, half)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := coverage[index]; math.Abs(have-0.5) > 0.01 {
+		t.Fatalf("have %f, want 0.5", have)
+	}
+	conv := &Converter{Resolution: res, Containment: ContainmentFull}
+	indexes, err := conv.ToH3(polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, i := range indexes {
+		found = found || i == index
+	}
+	if !found {
+		t.Fatalf("have no %s, want the cell fully within the polygon", h3.ToString(index))
+	}
+}
+
+func TestInvalidToH3Coverage(t *testing.T) {
+	if _, err := ToH3Coverage(7, nil); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	point := geojson.NewPoint(geometry.Point{X: -74.143609, Y: 40.751389})
+	if _, err := ToH3Coverage(7, point); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+	if _, err := ToH3Coverage(-1, point); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+}
@@ -0,0 +1,188 @@
+package geojson2h3
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tidwall/geojson"
+	"github.com/uber/h3-go/v3"
+)
+
+// Proximity is the grid proximity of two GeoJSON objects.
+type Proximity struct {
+	// Distance is the minimum grid distance between the hexagons of the objects,
+	// zero if the objects share a hexagon.
+	Distance int
+	// From and To are the nearest hexagons of the first and the second object.
+	From h3.H3Index
+	To   h3.H3Index
+	// Path is the line of hexagons from From to To, including both,
+	// or a shortest path of neighbours where the line is undefined around the pentagons.
+	Path []h3.H3Index
+}
+
+// ToH3Proximity converts two GeoJSON objects to hexagons with specified resolution
+// and returns their grid proximity.
+// See Converter.ToH3Proximity for the details.
+func ToH3Proximity(resolution int, a, b geojson.Object) (Proximity, error) {
+	c := Converter{Resolution: resolution}
+	return c.ToH3Proximity(a, b)
+}
+
+// ToH3Proximity converts two GeoJSON objects to hexagons and returns the minimum
+// grid distance between them, the nearest pair of hexagons and the grid path
+// between the pair. The ties are broken by the smallest hexagons.
+//
+// The rings around the smaller set of hexagons are expanded breadth-first
+// until a ring reaches the other set, so the distance is exact around the pentagons.
+// MaxCells limits the hexagons of each object and the hexagons visited by the search.
+// Compact is ignored.
+func (c *Converter) ToH3Proximity(a, b geojson.Object) (Proximity, error) {
+	if a == nil || b == nil {
+		return Proximity{}, fmt.Errorf("geojson.Object is nil")
+	}
+	conv := *c
+	conv.Compact = false
+	from, err := conv.ToH3(a)
+	if err != nil {
+		return Proximity{}, err
+	}
+	to, err := conv.ToH3(b)
+	if err != nil {
+		return Proximity{}, err
+	}
+	if len(from) == 0 || len(to) == 0 {
+		return Proximity{}, fmt.Errorf("got object without hexagons at resolution %d", c.Resolution)
+	}
+	fromSet, toSet := indexSet(from), indexSet(to)
+	for _, index := range sortCells(from) {
+		if _, ok := toSet[index]; ok {
+			return Proximity{From: index, To: index, Path: []h3.H3Index{index}}, nil
+		}
+	}
+	swapped := len(toSet) < len(fromSet)
+	if swapped {
+		fromSet, toSet = toSet, fromSet
+	}
+	targets, origins, parents, err := c.searchNearest(fromSet, toSet)
+	if err != nil {
+		return Proximity{}, err
+	}
+	// the nearest pair with the smallest From, then the smallest To
+	origin, target := origins[targets[0]], targets[0]
+	for _, index := range targets[1:] {
+		a, b := origins[index], index
+		if swapped {
+			if b < target || (b == target && a < origin) {
+				origin, target = a, b
+			}
+		} else if a < origin || (a == origin && b < target) {
+			origin, target = a, b
+		}
+	}
+	// the path of the search runs from the target back to the origin
+	path := make([]h3.H3Index, 0)
+	for index := target; ; index = parents[index] {
+		path = append(path, index)
+		if index == origin {
+			break
+		}
+	}
+	p := Proximity{Distance: len(path) - 1, From: origin, To: target}
+	if swapped {
+		p.From, p.To = target, origin
+	} else {
+		for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+			path[i], path[j] = path[j], path[i]
+		}
+	}
+	// the line is undefined or broken around the pentagons,
+	// the path of the search is used then
+	p.Path = path
+	if h3.DistanceBetween(p.From, p.To) == p.Distance {
+		if line := h3.Line(p.From, p.To); len(line) == p.Distance+1 && isConnected(line) {
+			p.Path = line
+		}
+	}
+	return p, nil
+}
+
+// searchNearest expands the rings around the boundary of the from set until
+// a ring reaches the to set. It returns the hexagons of the to set in that ring,
+// the smallest origin of each visited hexagon and their parents, leading back
+// to the origins.
+func (c *Converter) searchNearest(fromSet, toSet map[h3.H3Index]struct{}) (targets []h3.H3Index,
+	origins, parents map[h3.H3Index]h3.H3Index, err error) {
+	origins = make(map[h3.H3Index]h3.H3Index, len(fromSet))
+	parents = make(map[h3.H3Index]h3.H3Index, len(fromSet))
+	for index := range fromSet {
+		origins[index] = index
+		parents[index] = index
+	}
+	ring := boundaryCells(fromSet)
+	for len(ring) > 0 {
+		// the ring is expanded in the order of the origins,
+		// so each hexagon is reached first from its smallest origin
+		sort.Slice(ring, func(i, j int) bool {
+			if origins[ring[i]] != origins[ring[j]] {
+				return origins[ring[i]] < origins[ring[j]]
+			}
+			return ring[i] < ring[j]
+		})
+		next := make([]h3.H3Index, 0, len(ring)+6)
+		for _, index := range ring {
+			for _, neighbour := range neighbours(index) {
+				if _, ok := origins[neighbour]; ok {
+					continue
+				}
+				origins[neighbour] = origins[index]
+				parents[neighbour] = index
+				next = append(next, neighbour)
+				if _, ok := toSet[neighbour]; ok {
+					targets = append(targets, neighbour)
+				}
+			}
+		}
+		if len(targets) > 0 {
+			return targets, origins, parents, nil
+		}
+		if c.MaxCells > 0 && len(origins) > c.MaxCells {
+			return nil, nil, nil, fmt.Errorf("%w. got %d hexagons searched, expected <= %d hexagons",
+				ErrMaxCells, len(origins), c.MaxCells)
+		}
+		ring = next
+	}
+	return nil, nil, nil, fmt.Errorf("got no grid path between the objects at resolution %d", c.Resolution)
+}
+
+// isConnected reports whether each hexagon of the path is a neighbour of the previous one.
+func isConnected(path []h3.H3Index) bool {
+	for i := 1; i < len(path); i++ {
+		if !h3.AreNeighbors(path[i-1], path[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func indexSet(indexes []h3.H3Index) map[h3.H3Index]struct{} {
+	set := make(map[h3.H3Index]struct{}, len(indexes))
+	for _, index := range indexes {
+		set[index] = struct{}{}
+	}
+	return set
+}
+
+// boundaryCells returns the sorted hexagons of the set with a neighbour outside the set.
+func boundaryCells(set map[h3.H3Index]struct{}) []h3.H3Index {
+	cells := make([]h3.H3Index, 0)
+	for index := range set {
+		for _, neighbour := range neighbours(index) {
+			if _, ok := set[neighbour]; !ok {
+				cells = append(cells, index)
+				break
+			}
+		}
+	}
+	return sortCells(cells)
+}
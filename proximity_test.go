@@ -0,0 +1,148 @@
+package geojson2h3
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestPointsToH3Proximity(t *testing.T) {
+	a := geojson.NewPoint(geometry.Point{X: -73.95, Y: 40.75})
+	b := geojson.NewPoint(geometry.Point{X: -73.90, Y: 40.76})
+	have, err := ToH3Proximity(9, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 9)
+	to := h3.FromGeo(h3.GeoCoord{Latitude: 40.76, Longitude: -73.90}, 9)
+	if have.From != from || have.To != to {
+		t.Fatalf("have %s..%s, want %s..%s", h3.ToString(have.From), h3.ToString(have.To),
+			h3.ToString(from), h3.ToString(to))
+	}
+	if want := h3.DistanceBetween(from, to); have.Distance != want {
+		t.Fatalf("have %d, want %d", have.Distance, want)
+	}
+	if len(have.Path) != have.Distance+1 {
+		t.Fatalf("have %d, want %d", len(have.Path), have.Distance+1)
+	}
+	if have.Path[0] != from || have.Path[len(have.Path)-1] != to {
+		t.Fatalf("have path %s..%s, want %s..%s", h3.ToString(have.Path[0]),
+			h3.ToString(have.Path[len(have.Path)-1]), h3.ToString(from), h3.ToString(to))
+	}
+	for i := 1; i < len(have.Path); i++ {
+		if !h3.AreNeighbors(have.Path[i-1], have.Path[i]) {
+			t.Fatalf("have disconnected path at %d", i)
+		}
+	}
+}
+
+func TestPolygonsToH3Proximity(t *testing.T) {
+	a := geojson.NewPolygon(geometry.NewPoly(strToPoints(`
+[-74.00, 40.70],
+[-73.98, 40.70],
+[-73.98, 40.72],
+[-74.00, 40.72],
+[-74.00, 40.70]
+`), nil, nil))
+	b := geojson.NewPolygon(geometry.NewPoly(strToPoints(`
+[-73.95, 40.70],
+[-73.93, 40.70],
+[-73.93, 40.72],
+[-73.95, 40.72],
+[-73.95, 40.70]
+`), nil, nil))
+	have, err := ToH3Proximity(9, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, err := ToH3(9, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := ToH3(9, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := -1
+	for _, i := range from {
+		for _, j := range to {
+			if d := h3.DistanceBetween(i, j); want < 0 || d < want {
+				want = d
+			}
+		}
+	}
+	if have.Distance != want {
+		t.Fatalf("have %d, want %d", have.Distance, want)
+	}
+	if h3.DistanceBetween(have.From, have.To) != want {
+		t.Fatalf("have %d, want %d", h3.DistanceBetween(have.From, have.To), want)
+	}
+	have, err = ToH3Proximity(9, a, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have.Distance != 0 || have.From != have.To || len(have.Path) != 1 {
+		t.Fatalf("have %+v, want zero distance", have)
+	}
+}
+
+func TestPentagonToH3Proximity(t *testing.T) {
+	pentagon := h3.GetPentagonIndexes(5)[0]
+	ring := h3.KRing(pentagon, 2)
+	for _, a := range ring {
+		for _, b := range ring {
+			pointA := geojson.NewPoint(toPoint(h3.ToGeo(a)))
+			pointB := geojson.NewPoint(toPoint(h3.ToGeo(b)))
+			have, err := ToH3Proximity(5, pointA, pointB)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have.From != a || have.To != b {
+				t.Fatalf("have %s..%s, want %s..%s", h3.ToString(have.From), h3.ToString(have.To),
+					h3.ToString(a), h3.ToString(b))
+			}
+			if len(have.Path) != have.Distance+1 || have.Path[0] != a || have.Path[have.Distance] != b {
+				t.Fatalf("have path %v, want %d hexagons from %s to %s", have.Path, have.Distance+1,
+					h3.ToString(a), h3.ToString(b))
+			}
+			for i := 1; i < len(have.Path); i++ {
+				if !h3.AreNeighbors(have.Path[i-1], have.Path[i]) {
+					t.Fatalf("have disconnected path at %d", i)
+				}
+			}
+			if d := h3.DistanceBetween(a, b); d >= 0 && d < have.Distance {
+				t.Fatalf("have %d, want <= %d", have.Distance, d)
+			}
+		}
+	}
+}
+
+func TestMaxCellsToH3Proximity(t *testing.T) {
+	a := geojson.NewPoint(geometry.Point{X: -73.95, Y: 40.75})
+	b := geojson.NewPoint(geometry.Point{X: -70.0, Y: 42.0})
+	conv := &Converter{Resolution: 9, MaxCells: 1000}
+	if _, err := conv.ToH3Proximity(a, b); !errors.Is(err, ErrMaxCells) {
+		t.Fatalf("have %v, want %v", err, ErrMaxCells)
+	}
+}
+
+func TestInvalidToH3Proximity(t *testing.T) {
+	point := geojson.NewPoint(geometry.Point{X: -73.95, Y: 40.75})
+	if _, err := ToH3Proximity(9, nil, point); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := ToH3Proximity(9, point, nil); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := ToH3Proximity(16, point, point); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	conv := &Converter{Resolution: 9, Fallback: FallbackEmpty}
+	tiny := geojson.NewCircle(geometry.Point{X: -73.95, Y: 40.75}, 1, 16)
+	if _, err := conv.ToH3Proximity(tiny, point); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
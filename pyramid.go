@@ -0,0 +1,67 @@
+package geojson2h3
+
+import (
+	"fmt"
+
+	"github.com/tidwall/geojson"
+	"github.com/uber/h3-go/v3"
+)
+
+// ToH3Pyramid converts a GeoJSON objects to a list of hexagons per resolution,
+// from minResolution to maxResolution.
+// See Converter.ToH3Pyramid for the details.
+func ToH3Pyramid(minResolution, maxResolution int, o geojson.Object) (map[int][]h3.H3Index, error) {
+	c := Converter{Resolution: maxResolution}
+	return c.ToH3Pyramid(minResolution, o)
+}
+
+// ToH3Pyramid converts a GeoJSON objects to a list of hexagons per resolution,
+// from minResolution to the converter resolution.
+//
+// The object is converted only once at the converter resolution,
+// the coarser levels are the parents of the finer hexagons. So each level
+// covers the same hexagons as the finest one, and may slightly differ from
+// the conversion at the coarser resolution.
+//
+// MaxCells limits the number of hexagons of the finest level,
+// Compact compacts each level.
+func (c *Converter) ToH3Pyramid(minResolution int, o geojson.Object) (map[int][]h3.H3Index, error) {
+	if minResolution < 0 || minResolution > c.Resolution {
+		return nil, fmt.Errorf("got invalid resolution range %d..%d. expected 0 <= min <= max <= 15",
+			minResolution, c.Resolution)
+	}
+	conv := *c
+	conv.Compact = false
+	indexes, err := conv.ToH3(o)
+	if err != nil {
+		return nil, err
+	}
+	levels := make(map[int][]h3.H3Index, c.Resolution-minResolution+1)
+	levels[c.Resolution] = indexes
+	for resolution := c.Resolution - 1; resolution >= minResolution; resolution-- {
+		levels[resolution] = toParents(levels[resolution+1], resolution)
+	}
+	if c.Compact {
+		for resolution, indexes := range levels {
+			if len(indexes) > 0 {
+				levels[resolution] = h3.Compact(indexes)
+			}
+		}
+	}
+	return levels, nil
+}
+
+// toParents returns the unique parents of the hexagons in the order of the hexagons.
+func toParents(indexes []h3.H3Index, resolution int) []h3.H3Index {
+	visits := make(map[h3.H3Index]struct{}, len(indexes))
+	parents := make([]h3.H3Index, 0, len(indexes))
+	for _, index := range indexes {
+		parent := h3.ToParent(index, resolution)
+		if _, ok := visits[parent]; ok {
+			continue
+		}
+		visits[parent] = struct{}{}
+		parents = append(parents, parent)
+	}
+	return parents
+}
@@ -0,0 +1,82 @@
+package geojson2h3
+
+import (
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestToH3Pyramid(t *testing.T) {
+	points := strToPoints(`
+[-74.0, 40.7],
+[-73.9, 40.7],
+[-73.9, 40.8],
+[-74.0, 40.7]
+`)
+	polygon := geojson.NewPolygon(geometry.NewPoly(points, nil, nil))
+	levels, err := ToH3Pyramid(5, 10, polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(levels) != 6 {
+		t.Fatalf("have %d, want %d", len(levels), 6)
+	}
+	want, err := ToH3(10, polygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, want, levels[10])
+	for resolution := 9; resolution >= 5; resolution-- {
+		parents := make(map[h3.H3Index]struct{})
+		for _, index := range levels[resolution+1] {
+			parents[h3.ToParent(index, resolution)] = struct{}{}
+		}
+		if have, want := len(levels[resolution]), len(parents); have != want {
+			t.Fatalf("have %d, want %d", have, want)
+		}
+		for _, index := range levels[resolution] {
+			if h3.Resolution(index) != resolution {
+				t.Fatalf("have %d, want %d", h3.Resolution(index), resolution)
+			}
+			if _, ok := parents[index]; !ok {
+				t.Fatalf("have %s, want a parent of level %d", h3.ToString(index), resolution+1)
+			}
+		}
+	}
+}
+
+func TestCompactToH3Pyramid(t *testing.T) {
+	rect := geojson.NewRect(geometry.Rect{
+		Min: geometry.Point{X: -74.0, Y: 40.7},
+		Max: geometry.Point{X: -73.9, Y: 40.8},
+	})
+	conv := &Converter{Resolution: 9, Compact: true}
+	levels, err := conv.ToH3Pyramid(7, rect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ToH3(9, rect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := h3.Uncompact(levels[9], 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, want, have)
+}
+
+func TestInvalidToH3Pyramid(t *testing.T) {
+	point := geojson.NewPoint(geometry.Point{X: -74.0, Y: 40.7})
+	ranges := [][2]int{{-1, 5}, {6, 5}, {5, 16}}
+	for _, r := range ranges {
+		if _, err := ToH3Pyramid(r[0], r[1], point); err == nil {
+			t.Fatalf("have nil, want error for %d..%d", r[0], r[1])
+		}
+	}
+	if _, err := ToH3Pyramid(0, 5, nil); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
@@ -0,0 +1,184 @@
+package geojson2h3
+
+import (
+	"fmt"
+
+	"github.com/uber/h3-go/v3"
+)
+
+// FillHoles adds the holes of a set of hexagons up to maxSize hexagons,
+// a hole is a group of connected hexagons outside the set which is fully
+// enclosed by the set. The result is sorted.
+func FillHoles(indexes []h3.H3Index, maxSize int) ([]h3.H3Index, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("got invalid hole size %d. expected > 0", maxSize)
+	}
+	set, err := cellSet(indexes)
+	if err != nil {
+		return nil, err
+	}
+	visits := make(map[h3.H3Index]struct{})
+	holes := make([]h3.H3Index, 0)
+	for index := range set {
+		for _, neighbour := range neighbours(index) {
+			if _, ok := set[neighbour]; ok {
+				continue
+			}
+			if _, ok := visits[neighbour]; ok {
+				continue
+			}
+			hole, enclosed := floodHole(neighbour, set, maxSize)
+			for _, index := range hole {
+				visits[index] = struct{}{}
+			}
+			if enclosed {
+				holes = append(holes, hole...)
+			}
+		}
+	}
+	for _, index := range holes {
+		set[index] = struct{}{}
+	}
+	return setToCells(set), nil
+}
+
+// floodHole collects the hexagons outside the set connected to the start,
+// reporting whether there are at most maxSize of them.
+func floodHole(start h3.H3Index, set map[h3.H3Index]struct{}, maxSize int) ([]h3.H3Index, bool) {
+	visits := map[h3.H3Index]struct{}{start: {}}
+	hole := []h3.H3Index{start}
+	for i := 0; i < len(hole); i++ {
+		for _, neighbour := range neighbours(hole[i]) {
+			if _, ok := set[neighbour]; ok {
+				continue
+			}
+			if _, ok := visits[neighbour]; ok {
+				continue
+			}
+			if len(hole) == maxSize {
+				return hole, false
+			}
+			visits[neighbour] = struct{}{}
+			hole = append(hole, neighbour)
+		}
+	}
+	return hole, true
+}
+
+// RemoveSpurs removes the hexagons with less than minNeighbours neighbours
+// in the set, repeating until no such hexagons are left.
+// Use 1 to remove the isolated hexagons, 2 to remove the one-hexagon wide spurs as well.
+// The result is sorted.
+func RemoveSpurs(indexes []h3.H3Index, minNeighbours int) ([]h3.H3Index, error) {
+	if minNeighbours < 0 || minNeighbours > 6 {
+		return nil, fmt.Errorf("got invalid min neighbours %d. expected from 0 to 6", minNeighbours)
+	}
+	set, err := cellSet(indexes)
+	if err != nil {
+		return nil, err
+	}
+	queue := make([]h3.H3Index, 0, len(set))
+	for index := range set {
+		queue = append(queue, index)
+	}
+	for len(queue) > 0 {
+		index := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if _, ok := set[index]; !ok {
+			continue
+		}
+		inside := make([]h3.H3Index, 0, 6)
+		for _, neighbour := range neighbours(index) {
+			if _, ok := set[neighbour]; ok {
+				inside = append(inside, neighbour)
+			}
+		}
+		if len(inside) >= minNeighbours {
+			continue
+		}
+		delete(set, index)
+		queue = append(queue, inside...)
+	}
+	return setToCells(set), nil
+}
+
+// Dilate adds the hexagons within k steps of the set. The result is sorted.
+func Dilate(indexes []h3.H3Index, k int) ([]h3.H3Index, error) {
+	if k < 0 {
+		return nil, fmt.Errorf("got invalid k %d. expected >= 0", k)
+	}
+	set, err := cellSet(indexes)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[h3.H3Index]struct{}, len(set))
+	for index := range set {
+		for _, neighbour := range h3.KRing(index, k) {
+			result[neighbour] = struct{}{}
+		}
+	}
+	return setToCells(result), nil
+}
+
+// Erode removes the hexagons within k steps of the hexagons outside the set.
+// The result is sorted.
+func Erode(indexes []h3.H3Index, k int) ([]h3.H3Index, error) {
+	if k < 0 {
+		return nil, fmt.Errorf("got invalid k %d. expected >= 0", k)
+	}
+	set, err := cellSet(indexes)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[h3.H3Index]struct{}, len(set))
+	for index := range set {
+		inside := true
+		for _, neighbour := range h3.KRing(index, k) {
+			if _, ok := set[neighbour]; !ok {
+				inside = false
+				break
+			}
+		}
+		if inside {
+			result[index] = struct{}{}
+		}
+	}
+	return setToCells(result), nil
+}
+
+// cellSet returns the hexagons as a set, checking that all hexagons
+// are valid and have the same resolution.
+func cellSet(indexes []h3.H3Index) (map[h3.H3Index]struct{}, error) {
+	set := make(map[h3.H3Index]struct{}, len(indexes))
+	for i, index := range indexes {
+		if !h3.IsValid(index) {
+			return nil, fmt.Errorf("got invalid h3 index %x", uint64(index))
+		}
+		if i > 0 && h3.Resolution(index) != h3.Resolution(indexes[0]) {
+			return nil, fmt.Errorf("got mixed resolutions %d and %d, expected the same resolution",
+				h3.Resolution(indexes[0]), h3.Resolution(index))
+		}
+		set[index] = struct{}{}
+	}
+	return set, nil
+}
+
+func setToCells(set map[h3.H3Index]struct{}) []h3.H3Index {
+	cells := make([]h3.H3Index, 0, len(set))
+	for index := range set {
+		cells = append(cells, index)
+	}
+	return sortCells(cells)
+}
+
+// neighbours returns the hexagons sharing an edge with the hexagon.
+func neighbours(index h3.H3Index) []h3.H3Index {
+	ring := h3.KRing(index, 1)
+	result := ring[:0]
+	for _, neighbour := range ring {
+		if neighbour != index {
+			result = append(result, neighbour)
+		}
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package geojson2h3
+
+import (
+	"testing"
+
+	"github.com/uber/h3-go/v3"
+)
+
+func TestFillHoles(t *testing.T) {
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 9)
+	// a ring of radius 2 around the origin encloses a hole of 7 hexagons
+	ring, err := h3.HexRing(origin, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := FillHoles(ring, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(have) != len(ring) {
+		t.Fatalf("have %d, want %d", len(have), len(ring))
+	}
+	have, err = FillHoles(ring, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, h3.KRing(origin, 2), have)
+}
+
+func TestRemoveSpurs(t *testing.T) {
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 9)
+	disk := h3.KRing(origin, 2)
+	far := h3.KRing(origin, 10)
+	isolated := far[len(far)-1]
+	// a spur of two hexagons going out of the disk
+	line := h3.Line(origin, h3.KRing(origin, 4)[len(h3.KRing(origin, 4))-1])
+	spur := line[3:5]
+	indexes := append(append(append([]h3.H3Index{}, disk...), isolated), spur...)
+
+	have, err := RemoveSpurs(indexes, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, append(append([]h3.H3Index{}, disk...), spur...), have)
+	have, err = RemoveSpurs(indexes, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, disk, have)
+}
+
+func TestDilateErode(t *testing.T) {
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 9)
+	have, err := Dilate([]h3.H3Index{origin}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, h3.KRing(origin, 3), have)
+	have, err = Erode(have, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, h3.KRing(origin, 1), have)
+	have, err = Erode(have, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, h3.KRing(origin, 1), have)
+}
+
+func TestInvalidSmoothing(t *testing.T) {
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 9)
+	mixed := []h3.H3Index{origin, h3.ToParent(origin, 8)}
+	if _, err := FillHoles(mixed, 1); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := FillHoles([]h3.H3Index{origin}, 0); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := RemoveSpurs([]h3.H3Index{0}, 1); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := RemoveSpurs([]h3.H3Index{origin}, 7); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := Dilate(mixed, 1); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := Erode([]h3.H3Index{origin}, -1); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
@@ -0,0 +1,160 @@
+package geojson2h3
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/gjson"
+	"github.com/uber/h3-go/v3"
+)
+
+// TimeBucket is the number of point features within a hexagon and a time window.
+type TimeBucket struct {
+	Index h3.H3Index
+	// Start of the time window, in UTC.
+	Start time.Time
+	Count int
+}
+
+// ToH3TimeBuckets groups a GeoJSON point features by hexagons with specified resolution
+// and by time windows of the timestamp property.
+// See Converter.ToH3TimeBuckets for the details.
+func ToH3TimeBuckets(resolution int, o geojson.Object, property string, window time.Duration) ([]TimeBucket, error) {
+	c := Converter{Resolution: resolution}
+	return c.ToH3TimeBuckets(o, property, window)
+}
+
+// ToH3TimeBuckets groups a GeoJSON point features by hexagons and by time windows
+// of the timestamp property, returning the number of points per bucket sorted
+// by the window start and the hexagon.
+//
+// Known list of objects:
+//  - Feature of Point, MultiPoint, SimplePoint
+//  - FeatureCollection of the objects above
+//
+// The timestamp is either a RFC 3339 string or a number of seconds since
+// the Unix epoch. The windows are aligned to the Unix epoch.
+// Only the Resolution is used, the points are not limited by MaxCells.
+func (c *Converter) ToH3TimeBuckets(o geojson.Object, property string, window time.Duration) ([]TimeBucket, error) {
+	if o == nil {
+		return nil, fmt.Errorf("geojson.Object is nil")
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("got invalid time window %s. expected > 0", window)
+	}
+	if property == "" {
+		return nil, fmt.Errorf("got empty timestamp property")
+	}
+	b := &timeBuckets{
+		resolution: c.Resolution,
+		property:   property,
+		window:     int64(window),
+		counts:     make(map[timeBucketKey]int),
+	}
+	err := forEachGeometry(o, func(geom geojson.Object, feature *geojson.Feature) error {
+		if feature == nil {
+			return b.bucket(geom, 0, false)
+		}
+		t, err := featureTime(feature, b.property)
+		if err != nil {
+			return err
+		}
+		nanos := t.UnixNano()
+		offset := nanos % b.window
+		if offset < 0 {
+			offset += b.window
+		}
+		return b.bucket(geom, nanos-offset, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+	buckets := make([]TimeBucket, 0, len(b.counts))
+	for key, count := range b.counts {
+		buckets = append(buckets, TimeBucket{
+			Index: key.index,
+			Start: time.Unix(0, key.start).UTC(),
+			Count: count,
+		})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if !buckets[i].Start.Equal(buckets[j].Start) {
+			return buckets[i].Start.Before(buckets[j].Start)
+		}
+		return buckets[i].Index < buckets[j].Index
+	})
+	return buckets, nil
+}
+
+type timeBucketKey struct {
+	index h3.H3Index
+	start int64
+}
+
+type timeBuckets struct {
+	resolution int
+	property   string
+	window     int64
+	counts     map[timeBucketKey]int
+}
+
+func (b *timeBuckets) bucket(o geojson.Object, start int64, ok bool) (err error) {
+	switch typ := o.(type) {
+	case *geojson.MultiPoint:
+		typ.ForEach(func(geom geojson.Object) bool {
+			err = b.bucket(geom, start, ok)
+			return err == nil
+		})
+	case *geojson.Point:
+		err = b.add(pointToH3(b.resolution, typ)[0], start, ok)
+	case *geojson.SimplePoint:
+		err = b.add(simplePointToH3(b.resolution, typ)[0], start, ok)
+	default:
+		err = fmt.Errorf("expected geojson.Point or geojson.MultiPoint, got %T", o)
+	}
+	return
+}
+
+func (b *timeBuckets) add(index h3.H3Index, start int64, ok bool) error {
+	if !ok {
+		return fmt.Errorf("expected geojson.Feature with %q property", b.property)
+	}
+	b.counts[timeBucketKey{index: index, start: start}]++
+	return nil
+}
+
+// featureTime returns the timestamp of the feature property.
+func featureTime(feature *geojson.Feature, property string) (t time.Time, err error) {
+	err = fmt.Errorf("got feature without %q property", property)
+	gjson.Get(feature.Members(), "properties").ForEach(func(key, val gjson.Result) bool {
+		if key.String() != property {
+			return true
+		}
+		t, err = parseTimestamp(val, property)
+		return false
+	})
+	return
+}
+
+// parseTimestamp parses a RFC 3339 string or a number of seconds since the Unix epoch.
+func parseTimestamp(val gjson.Result, property string) (time.Time, error) {
+	switch val.Type {
+	case gjson.String:
+		t, err := time.Parse(time.RFC3339Nano, val.String())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("got invalid timestamp %s of %q property. %v", val.Raw, property, err)
+		}
+		return t, nil
+	case gjson.Number:
+		sec, frac := math.Modf(val.Float())
+		return time.Unix(int64(sec), int64(frac*1e9)), nil
+	default:
+		return time.Time{}, fmt.Errorf("got invalid timestamp %s of %q property", val.Raw, property)
+	}
+}
@@ -0,0 +1,83 @@
+package geojson2h3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tidwall/geojson"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestToH3TimeBuckets(t *testing.T) {
+	o, err := geojson.Parse(`{"type":"FeatureCollection","features":[
+{"type":"Feature","properties":{"ts":"2023-01-01T10:05:00Z"},"geometry":{"type":"Point","coordinates":[-73.95,40.75]}},
+{"type":"Feature","properties":{"ts":"2023-01-01T10:55:00+00:00"},"geometry":{"type":"Point","coordinates":[-73.95,40.75]}},
+{"type":"Feature","properties":{"ts":1672570800},"geometry":{"type":"Point","coordinates":[-73.95,40.75]}},
+{"type":"Feature","properties":{"ts":"2023-01-01T10:10:00Z"},"geometry":{"type":"MultiPoint","coordinates":[[-73.95,40.75],[-73.90,40.70]]}}
+]}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buckets, err := ToH3TimeBuckets(9, o, "ts", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.95}, 9)
+	b := h3.FromGeo(h3.GeoCoord{Latitude: 40.70, Longitude: -73.90}, 9)
+	ten := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	want := []TimeBucket{
+		{Index: a, Start: ten, Count: 3},
+		{Index: b, Start: ten, Count: 1},
+		{Index: a, Start: ten.Add(time.Hour), Count: 1},
+	}
+	if b < a {
+		want[0], want[1] = want[1], want[0]
+	}
+	if len(buckets) != len(want) {
+		t.Fatalf("have %d, want %d", len(buckets), len(want))
+	}
+	for i := range want {
+		if buckets[i] != want[i] {
+			t.Fatalf("have %v, want %v", buckets[i], want[i])
+		}
+	}
+}
+
+func TestInvalidToH3TimeBuckets(t *testing.T) {
+	parse := func(s string) geojson.Object {
+		o, err := geojson.Parse(s, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return o
+	}
+	feature := parse(`{"type":"Feature","properties":{"ts":"2023-01-01T10:05:00Z"},"geometry":{"type":"Point","coordinates":[-73.95,40.75]}}`)
+	if _, err := ToH3TimeBuckets(9, nil, "ts", time.Hour); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := ToH3TimeBuckets(16, feature, "ts", time.Hour); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := ToH3TimeBuckets(9, feature, "ts", 0); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	if _, err := ToH3TimeBuckets(9, feature, "", time.Hour); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	conv := &Converter{Resolution: 9, Containment: Containment(10)}
+	if _, err := conv.ToH3TimeBuckets(feature, "ts", time.Hour); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	objects := []geojson.Object{
+		parse(`{"type":"Point","coordinates":[-73.95,40.75]}`),
+		parse(`{"type":"Feature","properties":{},"geometry":{"type":"Point","coordinates":[-73.95,40.75]}}`),
+		parse(`{"type":"Feature","properties":{"ts":"yesterday"},"geometry":{"type":"Point","coordinates":[-73.95,40.75]}}`),
+		parse(`{"type":"Feature","properties":{"ts":true},"geometry":{"type":"Point","coordinates":[-73.95,40.75]}}`),
+		parse(`{"type":"Feature","properties":{"ts":0},"geometry":{"type":"LineString","coordinates":[[-73.95,40.75],[-73.9,40.7]]}}`),
+	}
+	for _, o := range objects {
+		if _, err := ToH3TimeBuckets(9, o, "ts", time.Hour); err == nil {
+			t.Fatalf("have nil, want error for %s", o)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package geojson2h3
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/tidwall/gjson"
+	"github.com/uber/h3-go/v3"
+)
+
+// CellVisit is a stay of a trajectory within a hexagon.
+type CellVisit struct {
+	Index h3.H3Index
+	// Enter and Exit are the times the trajectory enters and exits the hexagon,
+	// interpolated along the segments.
+	Enter time.Time
+	Exit  time.Time
+}
+
+// Dwell returns the time spent within the hexagon.
+func (v CellVisit) Dwell() time.Duration {
+	return v.Exit.Sub(v.Enter)
+}
+
+// ToH3Trajectory converts a GeoJSON line features with per-vertex timestamps
+// to the ordered visits of hexagons with specified resolution.
+// See Converter.ToH3Trajectory for the details.
+func ToH3Trajectory(resolution int, o geojson.Object, property string) ([]CellVisit, error) {
+	c := Converter{Resolution: resolution}
+	return c.ToH3Trajectory(o, property)
+}
+
+// ToH3Trajectory converts a GeoJSON line features with per-vertex timestamps
+// to the ordered visits of hexagons. The consecutive visits of the same hexagon
+// within a line are merged.
+//
+// Known list of objects:
+//  - Feature of LineString, MultiLineString
+//  - FeatureCollection of the objects above
+//
+// The property is an array of timestamps parallel to the coordinates,
+// an array of such arrays for MultiLineString. The timestamps are either
+// RFC 3339 strings or numbers of seconds since the Unix epoch, and must not decrease.
+// Only the Resolution is used, the lines are walked from hexagon to hexagon
+// instead of sampled by LineStep, and the visits are not limited by MaxCells.
+func (c *Converter) ToH3Trajectory(o geojson.Object, property string) ([]CellVisit, error) {
+	if o == nil {
+		return nil, fmt.Errorf("geojson.Object is nil")
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if property == "" {
+		return nil, fmt.Errorf("got empty timestamp property")
+	}
+	visits := make([]CellVisit, 0)
+	if err := trajectoryVisits(c.Resolution, o, property, &visits); err != nil {
+		return nil, err
+	}
+	return visits, nil
+}
+
+// DwellTimes returns the total time spent within each hexagon.
+func DwellTimes(visits []CellVisit) map[h3.H3Index]time.Duration {
+	dwell := make(map[h3.H3Index]time.Duration)
+	for _, visit := range visits {
+		dwell[visit.Index] += visit.Dwell()
+	}
+	return dwell
+}
+
+func trajectoryVisits(resolution int, o geojson.Object, property string, visits *[]CellVisit) error {
+	return forEachGeometry(o, func(geom geojson.Object, feature *geojson.Feature) (err error) {
+		if feature == nil {
+			return fmt.Errorf("GeoJSON invalid format. expected geojson.Feature, got %T", geom)
+		}
+		if geom != feature.Base() {
+			return fmt.Errorf("expected geojson.LineString or geojson.MultiLineString, got %T", feature.Base())
+		}
+		var timestamps gjson.Result
+		gjson.Get(feature.Members(), "properties").ForEach(func(key, val gjson.Result) bool {
+			if key.String() == property {
+				timestamps = val
+				return false
+			}
+			return true
+		})
+		if !timestamps.IsArray() {
+			return fmt.Errorf("got feature without %q timestamps array property", property)
+		}
+		switch typ := geom.(type) {
+		case *geojson.LineString:
+			err = lineVisits(resolution, typ.Base(), timestamps, property, visits)
+		case *geojson.MultiLineString:
+			lines := timestamps.Array()
+			i := 0
+			typ.ForEach(func(geom geojson.Object) bool {
+				lineString, ok := geom.(*geojson.LineString)
+				if !ok {
+					return true
+				}
+				if i >= len(lines) {
+					err = fmt.Errorf("got %d timestamps arrays, expected one per line", len(lines))
+					return false
+				}
+				err = lineVisits(resolution, lineString.Base(), lines[i], property, visits)
+				i++
+				return err == nil
+			})
+		default:
+			err = fmt.Errorf("expected geojson.LineString or geojson.MultiLineString, got %T", geom)
+		}
+		return
+	})
+}
+
+// lineVisits walks along the line from cell to cell, interpolating
+// the timestamps of the vertices.
+func lineVisits(resolution int, line *geometry.Line, timestamps gjson.Result, property string, visits *[]CellVisit) error {
+	if line.NumPoints() < 2 {
+		return fmt.Errorf("got %d points, expected >= 2 points", line.NumPoints())
+	}
+	values := timestamps.Array()
+	if len(values) != line.NumPoints() {
+		return fmt.Errorf("got %d timestamps, expected %d timestamps", len(values), line.NumPoints())
+	}
+	times := make([]time.Time, 0, len(values))
+	for i, value := range values {
+		t, err := parseTimestamp(value, property)
+		if err != nil {
+			return err
+		}
+		if i > 0 && t.Before(times[i-1]) {
+			return fmt.Errorf("got decreasing timestamps %s and %s", times[i-1].Format(time.RFC3339Nano),
+				t.Format(time.RFC3339Nano))
+		}
+		times = append(times, t)
+	}
+	first := len(*visits)
+	for i := 0; i < line.NumSegments(); i++ {
+		start, duration := times[i], times[i+1].Sub(times[i])
+		walkSegment(resolution, unwrapSegment(line.SegmentAt(i)), func(cell h3.H3Index, from, to float64) {
+			enter := start.Add(time.Duration(float64(duration) * from))
+			exit := start.Add(time.Duration(float64(duration) * to))
+			if n := len(*visits); n > first && (*visits)[n-1].Index == cell {
+				(*visits)[n-1].Exit = exit
+				return
+			}
+			*visits = append(*visits, CellVisit{Index: cell, Enter: enter, Exit: exit})
+		})
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package geojson2h3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tidwall/geojson"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestLineStringToH3Trajectory(t *testing.T) {
+	o, err := geojson.Parse(`{"type":"FeatureCollection","features":[
+{"type":"Feature","properties":{"ts":["2023-01-01T10:00:00Z","2023-01-01T10:05:00Z","2023-01-01T10:15:00Z","2023-01-01T10:20:00Z"]},
+"geometry":{"type":"LineString","coordinates":[[-73.95,40.75],[-73.94,40.75],[-73.94,40.75],[-73.94,40.76]]}}
+]}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	visits, err := ToH3Trajectory(9, o, "ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	if !visits[0].Enter.Equal(start) {
+		t.Fatalf("have %v, want %v", visits[0].Enter, start)
+	}
+	if end := start.Add(20 * time.Minute); !visits[len(visits)-1].Exit.Equal(end) {
+		t.Fatalf("have %v, want %v", visits[len(visits)-1].Exit, end)
+	}
+	for i := 1; i < len(visits); i++ {
+		if visits[i-1].Index == visits[i].Index {
+			t.Fatalf("have repeated visit of %s, want merged visits", h3.ToString(visits[i].Index))
+		}
+		if !visits[i-1].Exit.Equal(visits[i].Enter) {
+			t.Fatalf("have %v, want %v", visits[i].Enter, visits[i-1].Exit)
+		}
+	}
+	lengths, err := ToH3Lengths(9, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dwell := DwellTimes(visits)
+	if len(dwell) != len(lengths) {
+		t.Fatalf("have %d, want %d", len(dwell), len(lengths))
+	}
+	total := time.Duration(0)
+	for index, d := range dwell {
+		if _, ok := lengths[index]; !ok {
+			t.Fatalf("have %s, want a hexagon along the line", h3.ToString(index))
+		}
+		total += d
+	}
+	if total != 20*time.Minute {
+		t.Fatalf("have %v, want %v", total, 20*time.Minute)
+	}
+	// the vehicle stops for 10 minutes at the corner
+	corner := h3.FromGeo(h3.GeoCoord{Latitude: 40.75, Longitude: -73.94}, 9)
+	if dwell[corner] < 10*time.Minute {
+		t.Fatalf("have %v, want >= %v", dwell[corner], 10*time.Minute)
+	}
+}
+
+func TestMultiLineStringToH3Trajectory(t *testing.T) {
+	o, err := geojson.Parse(`{"type":"Feature","properties":{"ts":[[0,60],[120,300]]},
+"geometry":{"type":"MultiLineString","coordinates":[[[-73.95,40.75],[-73.94,40.75]],[[-73.93,40.75],[-73.92,40.75]]]}}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	visits, err := ToH3Trajectory(9, o, "ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := time.Duration(0)
+	for _, d := range DwellTimes(visits) {
+		total += d
+	}
+	if total != 4*time.Minute {
+		t.Fatalf("have %v, want %v", total, 4*time.Minute)
+	}
+}
+
+func TestInvalidToH3Trajectory(t *testing.T) {
+	objects := []string{
+		`{"type":"LineString","coordinates":[[-73.95,40.75],[-73.94,40.75]]}`,
+		`{"type":"Feature","properties":{},"geometry":{"type":"LineString","coordinates":[[-73.95,40.75],[-73.94,40.75]]}}`,
+		`{"type":"Feature","properties":{"ts":[0]},"geometry":{"type":"LineString","coordinates":[[-73.95,40.75],[-73.94,40.75]]}}`,
+		`{"type":"Feature","properties":{"ts":[60,0]},"geometry":{"type":"LineString","coordinates":[[-73.95,40.75],[-73.94,40.75]]}}`,
+		`{"type":"Feature","properties":{"ts":[0,"later"]},"geometry":{"type":"LineString","coordinates":[[-73.95,40.75],[-73.94,40.75]]}}`,
+		`{"type":"Feature","properties":{"ts":[0]},"geometry":{"type":"Point","coordinates":[-73.95,40.75]}}`,
+		`{"type":"Feature","properties":{"ts":[[0,60]]},"geometry":{"type":"MultiLineString","coordinates":[[[-73.95,40.75],[-73.94,40.75]],[[-73.93,40.75],[-73.92,40.75]]]}}`,
+	}
+	for _, s := range objects {
+		o, err := geojson.Parse(s, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ToH3Trajectory(9, o, "ts"); err == nil {
+			t.Fatalf("have nil, want error for %s", s)
+		}
+	}
+	if _, err := ToH3Trajectory(9, nil, "ts"); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+	o, err := geojson.Parse(`{"type":"Feature","properties":{"ts":[0,60]},"geometry":{"type":"LineString","coordinates":[[-73.95,40.75],[-73.94,40.75]]}}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv := &Converter{Resolution: 9, Containment: Containment(10)}
+	if _, err := conv.ToH3Trajectory(o, "ts"); err == nil {
+		t.Fatalf("have nil, want error")
+	}
+}
@@ -0,0 +1,312 @@
+package geojson2h3
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+const (
+	wkbPoint              = 1
+	wkbLineString         = 2
+	wkbPolygon            = 3
+	wkbMultiPoint         = 4
+	wkbMultiLineString    = 5
+	wkbMultiPolygon       = 6
+	wkbGeometryCollection = 7
+
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// ParseWKB parses a Well-Known Binary geometry to a GeoJSON object
+// which can be converted with ToH3.
+//
+// Known list of geometries:
+//  - Point, MultiPoint
+//  - LineString, MultiLineString
+//  - Polygon, MultiPolygon
+//  - GeometryCollection
+//
+// ISO and PostGIS EWKB flavours of Z, M and ZM geometries are accepted,
+// only the Z value of a Point is kept.
+func ParseWKB(wkb []byte) (geojson.Object, error) {
+	r := &wkbReader{data: wkb}
+	o, err := r.readGeometry()
+	if err != nil {
+		return nil, err
+	}
+	if r.pos != len(r.data) {
+		return nil, fmt.Errorf("WKB invalid format. got %d trailing bytes", len(r.data)-r.pos)
+	}
+	return o, nil
+}
+
+// ToWKB converts a set of hexagons to a little-endian Well-Known Binary `MultiPolygon`
+// with the set outline(s).
+func ToWKB(indexes []h3.H3Index) ([]byte, error) {
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("uber h3 indexes are empty")
+	}
+	polygons := toPolygons(indexes)
+	dst := appendWKBHeader(nil, wkbMultiPolygon, len(polygons))
+	for _, polygon := range polygons {
+		dst = appendWKBHeader(dst, wkbPolygon, 1+len(polygon.Holes))
+		dst = appendWKBRing(dst, polygon.Exterior)
+		for _, hole := range polygon.Holes {
+			dst = appendWKBRing(dst, hole)
+		}
+	}
+	return dst, nil
+}
+
+func appendWKBHeader(dst []byte, typ uint32, n int) []byte {
+	dst = append(dst, 1)
+	dst = appendUint32(dst, typ)
+	return appendUint32(dst, uint32(n))
+}
+
+func appendWKBRing(dst []byte, ring geometry.Ring) []byte {
+	dst = appendUint32(dst, uint32(ring.NumPoints()))
+	for i := 0; i < ring.NumPoints(); i++ {
+		point := ring.PointAt(i)
+		dst = appendUint64(dst, math.Float64bits(point.X))
+		dst = appendUint64(dst, math.Float64bits(point.Y))
+	}
+	return dst
+}
+
+func appendUint32(dst []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	return append(dst, buf[:]...)
+}
+
+func appendUint64(dst []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(dst, buf[:]...)
+}
+
+type wkbReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	if len(r.data)-r.pos < 4 {
+		return 0, fmt.Errorf("WKB invalid format. unexpected end of data")
+	}
+	v := r.order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+func (r *wkbReader) readFloat64() (float64, error) {
+	if len(r.data)-r.pos < 8 {
+		return 0, fmt.Errorf("WKB invalid format. unexpected end of data")
+	}
+	v := math.Float64frombits(r.order.Uint64(r.data[r.pos:]))
+	r.pos += 8
+	return v, nil
+}
+
+// readCount reads the number of items, each of them at least size bytes long.
+func (r *wkbReader) readCount(size int) (int, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return 0, err
+	}
+	if int64(n)*int64(size) > int64(len(r.data)-r.pos) {
+		return 0, fmt.Errorf("WKB invalid format. got %d items, data is too short", n)
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) readGeometry() (geojson.Object, error) {
+	if r.pos >= len(r.data) {
+		return nil, fmt.Errorf("WKB invalid format. unexpected end of data")
+	}
+	switch r.data[r.pos] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return nil, fmt.Errorf("WKB invalid format. unknown byte order %d", r.data[r.pos])
+	}
+	r.pos++
+	typ, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	hasZ, hasM := typ&ewkbZ != 0, typ&ewkbM != 0
+	if typ&ewkbSRID != 0 {
+		if _, err := r.readUint32(); err != nil {
+			return nil, err
+		}
+	}
+	typ &^= ewkbZ | ewkbM | ewkbSRID
+	switch typ / 1000 {
+	case 1:
+		hasZ = true
+	case 2:
+		hasM = true
+	case 3:
+		hasZ, hasM = true, true
+	}
+	dims := 2
+	if hasZ {
+		dims++
+	}
+	if hasM {
+		dims++
+	}
+
+	switch typ % 1000 {
+	case wkbPoint:
+		coords, err := r.readCoords(dims)
+		if err != nil {
+			return nil, err
+		}
+		// PostGIS writes an empty Point as NaN coordinates
+		if math.IsNaN(coords[0]) && math.IsNaN(coords[1]) {
+			return nil, fmt.Errorf("WKB empty Point is not supported")
+		}
+		if err := checkCoord("WKB", coords[0], coords[1]); err != nil {
+			return nil, err
+		}
+		point := geometry.Point{X: coords[0], Y: coords[1]}
+		if hasZ {
+			return geojson.NewPointZ(point, coords[2]), nil
+		}
+		return geojson.NewPoint(point), nil
+	case wkbLineString:
+		points, err := r.readPoints(dims, "LineString")
+		if err != nil {
+			return nil, err
+		}
+		return geojson.NewLineString(geometry.NewLine(points, nil)), nil
+	case wkbPolygon:
+		poly, err := r.readPoly(dims)
+		if err != nil {
+			return nil, err
+		}
+		return geojson.NewPolygon(poly), nil
+	case wkbMultiPoint, wkbMultiLineString, wkbMultiPolygon, wkbGeometryCollection:
+		n, err := r.readCount(5)
+		if err != nil {
+			return nil, err
+		}
+		objects := make([]geojson.Object, 0, n)
+		for i := 0; i < n; i++ {
+			o, err := r.readGeometry()
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, o)
+		}
+		return wkbCollection(typ%1000, objects)
+	default:
+		return nil, fmt.Errorf("unknown WKB geometry type %d", typ)
+	}
+}
+
+// wkbCollection builds a multi geometry from its members.
+func wkbCollection(typ uint32, objects []geojson.Object) (geojson.Object, error) {
+	switch typ {
+	case wkbMultiPoint:
+		points := make([]geometry.Point, 0, len(objects))
+		for _, o := range objects {
+			point, ok := o.(*geojson.Point)
+			if !ok {
+				return nil, fmt.Errorf("WKB invalid format. expected Point, got %T", o)
+			}
+			points = append(points, point.Base())
+		}
+		return geojson.NewMultiPoint(points), nil
+	case wkbMultiLineString:
+		lines := make([]*geometry.Line, 0, len(objects))
+		for _, o := range objects {
+			lineString, ok := o.(*geojson.LineString)
+			if !ok {
+				return nil, fmt.Errorf("WKB invalid format. expected LineString, got %T", o)
+			}
+			lines = append(lines, lineString.Base())
+		}
+		return geojson.NewMultiLineString(lines), nil
+	case wkbMultiPolygon:
+		polys := make([]*geometry.Poly, 0, len(objects))
+		for _, o := range objects {
+			polygon, ok := o.(*geojson.Polygon)
+			if !ok {
+				return nil, fmt.Errorf("WKB invalid format. expected Polygon, got %T", o)
+			}
+			polys = append(polys, polygon.Base())
+		}
+		return geojson.NewMultiPolygon(polys), nil
+	default:
+		return geojson.NewGeometryCollection(objects), nil
+	}
+}
+
+func (r *wkbReader) readPoly(dims int) (*geometry.Poly, error) {
+	n, err := r.readCount(4)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("WKB empty Polygon is not supported")
+	}
+	rings := make([][]geometry.Point, 0, n)
+	for i := 0; i < n; i++ {
+		points, err := r.readPoints(dims, "Polygon ring")
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, points)
+	}
+	return geometry.NewPoly(rings[0], rings[1:], nil), nil
+}
+
+// readPoints reads the points of a non-empty LineString or Polygon ring,
+// the typ names it in the errors.
+func (r *wkbReader) readPoints(dims int, typ string) ([]geometry.Point, error) {
+	n, err := r.readCount(8 * dims)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("WKB empty %s is not supported", typ)
+	}
+	points := make([]geometry.Point, 0, n)
+	for i := 0; i < n; i++ {
+		coords, err := r.readCoords(dims)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCoord("WKB", coords[0], coords[1]); err != nil {
+			return nil, err
+		}
+		points = append(points, geometry.Point{X: coords[0], Y: coords[1]})
+	}
+	return points, nil
+}
+
+func (r *wkbReader) readCoords(dims int) ([]float64, error) {
+	coords := make([]float64, dims)
+	for i := 0; i < dims; i++ {
+		v, err := r.readFloat64()
+		if err != nil {
+			return nil, err
+		}
+		coords[i] = v
+	}
+	return coords, nil
+}
@@ -0,0 +1,148 @@
+package geojson2h3
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestParseWKB(t *testing.T) {
+	testCases := []struct {
+		name string
+		wkb  string
+		want string
+		err  bool
+	}{
+		{
+			name: "point",
+			wkb:  "0101000000000000000000f03f0000000000000040",
+			want: `{"type":"Point","coordinates":[1,2]}`,
+		},
+		{
+			name: "big endian point",
+			wkb:  "00000000013ff00000000000004000000000000000",
+			want: `{"type":"Point","coordinates":[1,2]}`,
+		},
+		{
+			name: "iso point z",
+			wkb:  "01e9030000000000000000f03f00000000000000400000000000000840",
+			want: `{"type":"Point","coordinates":[1,2,3]}`,
+		},
+		{
+			name: "ewkb point z with srid",
+			wkb:  "01010000a0e6100000000000000000f03f00000000000000400000000000000840",
+			want: `{"type":"Point","coordinates":[1,2,3]}`,
+		},
+		{
+			name: "linestring",
+			wkb:  "010200000002000000000000000000f03f000000000000004000000000000008400000000000001040",
+			want: `{"type":"LineString","coordinates":[[1,2],[3,4]]}`,
+		},
+		{
+			name: "polygon",
+			wkb: "01030000000100000004000000" +
+				"00000000000000000000000000000000" +
+				"000000000000f03f0000000000000000" +
+				"000000000000f03f000000000000f03f" +
+				"00000000000000000000000000000000",
+			want: `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`,
+		},
+		{
+			name: "multipoint",
+			wkb: "010400000002000000" +
+				"0101000000000000000000f03f0000000000000040" +
+				"010100000000000000000008400000000000001040",
+			want: `{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`,
+		},
+		{
+			name: "multilinestring",
+			wkb: "010500000001000000" +
+				"010200000002000000000000000000f03f000000000000004000000000000008400000000000001040",
+			want: `{"type":"MultiLineString","coordinates":[[[1,2],[3,4]]]}`,
+		},
+		{
+			name: "geometrycollection",
+			wkb: "010700000001000000" +
+				"0101000000000000000000f03f0000000000000040",
+			want: `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]}]}`,
+		},
+		{name: "empty", wkb: "", err: true},
+		{name: "invalid byte order", wkb: "0201000000", err: true},
+		{name: "unknown type", wkb: "0109000000", err: true},
+		{name: "truncated point", wkb: "0101000000000000000000f03f", err: true},
+		{name: "too many points", wkb: "0102000000ffffffff", err: true},
+		{name: "trailing bytes", wkb: "0101000000000000000000f03f000000000000004000", err: true},
+		{name: "empty point", wkb: "0101000000000000000000f87f000000000000f87f", err: true},
+		{name: "infinite point", wkb: "0101000000000000000000f07f0000000000000040", err: true},
+		{name: "empty linestring", wkb: "010200000000000000", err: true},
+		{name: "empty polygon ring", wkb: "01030000000100000000000000", err: true},
+		{
+			name: "nan linestring",
+			wkb:  "010200000002000000000000000000f03f0000000000000040000000000000f87f0000000000001040",
+			err:  true,
+		},
+		{
+			name: "invalid multipoint member",
+			wkb: "010400000001000000" +
+				"010200000002000000000000000000f03f000000000000004000000000000008400000000000001040",
+			err: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tc.wkb)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o, err := ParseWKB(data)
+			if err != nil {
+				if !tc.err {
+					t.Fatal(err)
+				}
+				return
+			}
+			if tc.err {
+				t.Fatalf("have nil, expected error")
+			}
+			if have := o.JSON(); have != tc.want {
+				t.Fatalf("have %s, want %s", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestToWKB(t *testing.T) {
+	res := 8
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}, res)
+	ring, err := h3.HexRing(origin, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexes := append(ring, h3.FromGeo(h3.GeoCoord{Latitude: 40.547124, Longitude: -73.923951}, res))
+	wkb, err := ToWKB(indexes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := ParseWKB(wkb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	multiPolygon, ok := o.(*geojson.MultiPolygon)
+	if !ok {
+		t.Fatalf("have %T, want *geojson.MultiPolygon", o)
+	}
+	if want, have := 2, len(multiPolygon.Children()); want != have {
+		t.Fatalf("have %d polygons, want %d", have, want)
+	}
+	have, err := ToH3(res, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, indexes, have)
+
+	if _, err := ToWKB(nil); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+}
@@ -0,0 +1,341 @@
+package geojson2h3
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/geojson"
+	"github.com/tidwall/geojson/geometry"
+	"github.com/uber/h3-go/v3"
+)
+
+// ParseWKT parses a Well-Known Text geometry to a GeoJSON object
+// which can be converted with ToH3.
+//
+// Known list of geometries:
+//  - POINT, MULTIPOINT
+//  - LINESTRING, MULTILINESTRING
+//  - POLYGON, MULTIPOLYGON
+//  - GEOMETRYCOLLECTION
+//
+// Z, M and ZM geometries and the EWKT `SRID=<srid>;` prefix are accepted,
+// only the Z value of a POINT is kept.
+func ParseWKT(wkt string) (geojson.Object, error) {
+	p := &wktParser{input: wkt}
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(wkt)), "SRID=") {
+		i := strings.IndexByte(wkt, ';')
+		if i < 0 {
+			return nil, fmt.Errorf("WKT invalid format. expected ';' after SRID")
+		}
+		p.pos = i + 1
+	}
+	o, err := p.parseGeometry()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.next(); tok != "" {
+		return nil, fmt.Errorf("WKT invalid format. unexpected %q at %d", tok, p.pos)
+	}
+	return o, nil
+}
+
+// ToWKT converts a set of hexagons to a Well-Known Text `MULTIPOLYGON`
+// with the set outline(s).
+func ToWKT(indexes []h3.H3Index) (string, error) {
+	if len(indexes) == 0 {
+		return "", fmt.Errorf("uber h3 indexes are empty")
+	}
+	var sb strings.Builder
+	sb.WriteString("MULTIPOLYGON (")
+	for i, polygon := range toPolygons(indexes) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		writeWKTRing(&sb, polygon.Exterior)
+		for _, hole := range polygon.Holes {
+			sb.WriteString(", ")
+			writeWKTRing(&sb, hole)
+		}
+		sb.WriteString(")")
+	}
+	sb.WriteString(")")
+	return sb.String(), nil
+}
+
+func writeWKTRing(sb *strings.Builder, ring geometry.Ring) {
+	sb.WriteString("(")
+	for i := 0; i < ring.NumPoints(); i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		point := ring.PointAt(i)
+		sb.WriteString(strconv.FormatFloat(point.X, 'f', -1, 64))
+		sb.WriteString(" ")
+		sb.WriteString(strconv.FormatFloat(point.Y, 'f', -1, 64))
+	}
+	sb.WriteString(")")
+}
+
+type wktParser struct {
+	input string
+	pos   int
+}
+
+// next returns the next token: a word, a number or one of "(", ")", ",".
+// An empty token means the end of the input.
+func (p *wktParser) next() string {
+	for p.pos < len(p.input) && isWKTSpace(p.input[p.pos]) {
+		p.pos++
+	}
+	if p.pos >= len(p.input) {
+		return ""
+	}
+	start := p.pos
+	switch p.input[p.pos] {
+	case '(', ')', ',':
+		p.pos++
+		return p.input[start:p.pos]
+	}
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if isWKTSpace(c) || c == '(' || c == ')' || c == ',' {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *wktParser) peek() string {
+	pos := p.pos
+	tok := p.next()
+	p.pos = pos
+	return tok
+}
+
+func (p *wktParser) expect(want string) error {
+	if tok := p.next(); tok != want {
+		return fmt.Errorf("WKT invalid format. expected %q, got %q", want, tok)
+	}
+	return nil
+}
+
+func (p *wktParser) parseGeometry() (geojson.Object, error) {
+	typ := strings.ToUpper(p.next())
+	onlyM := false
+	switch strings.ToUpper(p.peek()) {
+	case "Z", "ZM":
+		p.next()
+	case "M":
+		onlyM = true
+		p.next()
+	}
+	if strings.ToUpper(p.peek()) == "EMPTY" {
+		p.next()
+		if typ == "GEOMETRYCOLLECTION" {
+			return geojson.NewGeometryCollection(nil), nil
+		}
+		return nil, fmt.Errorf("WKT empty %s is not supported", typ)
+	}
+	switch typ {
+	case "POINT":
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		coords, err := p.parseCoords()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		if math.IsNaN(coords[0]) && math.IsNaN(coords[1]) {
+			return nil, fmt.Errorf("WKT empty %s is not supported", typ)
+		}
+		if err := checkCoord("WKT", coords[0], coords[1]); err != nil {
+			return nil, err
+		}
+		point := geometry.Point{X: coords[0], Y: coords[1]}
+		if len(coords) > 2 && !onlyM {
+			return geojson.NewPointZ(point, coords[2]), nil
+		}
+		return geojson.NewPoint(point), nil
+	case "LINESTRING":
+		points, err := p.parsePoints()
+		if err != nil {
+			return nil, err
+		}
+		return geojson.NewLineString(geometry.NewLine(points, nil)), nil
+	case "POLYGON":
+		poly, err := p.parsePoly()
+		if err != nil {
+			return nil, err
+		}
+		return geojson.NewPolygon(poly), nil
+	case "MULTIPOINT":
+		points, err := p.parseMultiPoint()
+		if err != nil {
+			return nil, err
+		}
+		return geojson.NewMultiPoint(points), nil
+	case "MULTILINESTRING":
+		lines := make([]*geometry.Line, 0)
+		err := p.parseList(func() error {
+			points, err := p.parsePoints()
+			if err != nil {
+				return err
+			}
+			lines = append(lines, geometry.NewLine(points, nil))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return geojson.NewMultiLineString(lines), nil
+	case "MULTIPOLYGON":
+		polys := make([]*geometry.Poly, 0)
+		err := p.parseList(func() error {
+			poly, err := p.parsePoly()
+			if err != nil {
+				return err
+			}
+			polys = append(polys, poly)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return geojson.NewMultiPolygon(polys), nil
+	case "GEOMETRYCOLLECTION":
+		objects := make([]geojson.Object, 0)
+		err := p.parseList(func() error {
+			o, err := p.parseGeometry()
+			if err != nil {
+				return err
+			}
+			objects = append(objects, o)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return geojson.NewGeometryCollection(objects), nil
+	default:
+		return nil, fmt.Errorf("unknown WKT geometry %q", typ)
+	}
+}
+
+// parseList parses a parenthesized comma-separated list of items.
+func (p *wktParser) parseList(item func() error) error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+		switch tok := p.next(); tok {
+		case ",":
+			continue
+		case ")":
+			return nil
+		default:
+			return fmt.Errorf("WKT invalid format. expected \",\" or \")\", got %q", tok)
+		}
+	}
+}
+
+func (p *wktParser) parsePoly() (*geometry.Poly, error) {
+	rings := make([][]geometry.Point, 0, 1)
+	err := p.parseList(func() error {
+		points, err := p.parsePoints()
+		if err != nil {
+			return err
+		}
+		rings = append(rings, points)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return geometry.NewPoly(rings[0], rings[1:], nil), nil
+}
+
+// parseMultiPoint accepts both `(1 2, 3 4)` and `((1 2), (3 4))` notations.
+func (p *wktParser) parseMultiPoint() ([]geometry.Point, error) {
+	points := make([]geometry.Point, 0)
+	err := p.parseList(func() error {
+		nested := p.peek() == "("
+		if nested {
+			p.next()
+		}
+		coords, err := p.parseCoords()
+		if err != nil {
+			return err
+		}
+		if nested {
+			if err := p.expect(")"); err != nil {
+				return err
+			}
+		}
+		if err := checkCoord("WKT", coords[0], coords[1]); err != nil {
+			return err
+		}
+		points = append(points, geometry.Point{X: coords[0], Y: coords[1]})
+		return nil
+	})
+	return points, err
+}
+
+func (p *wktParser) parsePoints() ([]geometry.Point, error) {
+	points := make([]geometry.Point, 0)
+	err := p.parseList(func() error {
+		coords, err := p.parseCoords()
+		if err != nil {
+			return err
+		}
+		if err := checkCoord("WKT", coords[0], coords[1]); err != nil {
+			return err
+		}
+		points = append(points, geometry.Point{X: coords[0], Y: coords[1]})
+		return nil
+	})
+	return points, err
+}
+
+// parseCoords parses from 2 to 4 space-separated numbers.
+func (p *wktParser) parseCoords() ([]float64, error) {
+	coords := make([]float64, 0, 4)
+	for len(coords) < 4 {
+		tok := p.peek()
+		if tok == "" || tok == "," || tok == ")" || tok == "(" {
+			break
+		}
+		p.next()
+		v, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return nil, fmt.Errorf("WKT invalid format. expected number, got %q", tok)
+		}
+		coords = append(coords, v)
+	}
+	if len(coords) < 2 {
+		return nil, fmt.Errorf("WKT invalid format. got %d coordinates, expected >= 2", len(coords))
+	}
+	return coords, nil
+}
+
+// checkCoord returns an error if the coordinate is NaN or infinite,
+// which has no hexagon.
+func checkCoord(format string, x, y float64) error {
+	if math.IsNaN(x) || math.IsNaN(y) || math.IsInf(x, 0) || math.IsInf(y, 0) {
+		return fmt.Errorf("%s invalid format. got invalid coordinate %v %v", format, x, y)
+	}
+	return nil
+}
+
+func isWKTSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
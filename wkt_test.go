@@ -0,0 +1,144 @@
+package geojson2h3
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/tidwall/geojson"
+	"github.com/uber/h3-go/v3"
+)
+
+func TestParseWKT(t *testing.T) {
+	testCases := []struct {
+		name string
+		wkt  string
+		want string
+		err  bool
+	}{
+		{
+			name: "point",
+			wkt:  "POINT (-74.143609 40.751389)",
+			want: `{"type":"Point","coordinates":[-74.143609,40.751389]}`,
+		},
+		{
+			name: "point z",
+			wkt:  "POINT Z (-74.143609 40.751389 12.5)",
+			want: `{"type":"Point","coordinates":[-74.143609,40.751389,12.5]}`,
+		},
+		{
+			name: "point m",
+			wkt:  "point m (-74.143609 40.751389 12.5)",
+			want: `{"type":"Point","coordinates":[-74.143609,40.751389]}`,
+		},
+		{
+			name: "ewkt",
+			wkt:  "SRID=4326;LINESTRING(-73.992074 40.719831,-73.992026 40.719949)",
+			want: `{"type":"LineString","coordinates":[[-73.992074,40.719831],[-73.992026,40.719949]]}`,
+		},
+		{
+			name: "polygon with hole",
+			wkt:  "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 2 4, 4 4, 2 2))",
+			want: `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2,2],[2,4],[4,4],[2,2]]]}`,
+		},
+		{
+			name: "multipoint",
+			wkt:  "MULTIPOINT ((1 2), (3 4))",
+			want: `{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`,
+		},
+		{
+			name: "multipoint without parentheses",
+			wkt:  "MULTIPOINT (1 2, 3 4)",
+			want: `{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`,
+		},
+		{
+			name: "multilinestring",
+			wkt:  "MULTILINESTRING ((1 2, 3 4), (5 6, 7 8))",
+			want: `{"type":"MultiLineString","coordinates":[[[1,2],[3,4]],[[5,6],[7,8]]]}`,
+		},
+		{
+			name: "multipolygon",
+			wkt:  "MULTIPOLYGON Z (((0 0 1, 1 0 1, 1 1 1, 0 0 1)), ((5 5 1, 6 5 1, 6 6 1, 5 5 1)))",
+			want: `{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]],[[[5,5],[6,5],[6,6],[5,5]]]]}`,
+		},
+		{
+			name: "geometrycollection",
+			wkt:  "GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (1 2, 3 4))",
+			want: `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]},{"type":"LineString","coordinates":[[1,2],[3,4]]}]}`,
+		},
+		{
+			name: "empty geometrycollection",
+			wkt:  "GEOMETRYCOLLECTION EMPTY",
+			want: `{"type":"GeometryCollection","geometries":[]}`,
+		},
+		{name: "empty point", wkt: "POINT EMPTY", err: true},
+		{name: "nan point", wkt: "POINT (NaN NaN)", err: true},
+		{name: "infinite point", wkt: "POINT (Inf 2)", err: true},
+		{name: "nan linestring", wkt: "LINESTRING (1 2, NaN 4)", err: true},
+		{name: "infinite multipoint", wkt: "MULTIPOINT ((1 2), (3 -Inf))", err: true},
+		{name: "unknown geometry", wkt: "CIRCLE (1 2)", err: true},
+		{name: "invalid number", wkt: "POINT (1 a)", err: true},
+		{name: "missing coordinate", wkt: "POINT (1)", err: true},
+		{name: "unclosed list", wkt: "LINESTRING (1 2, 3 4", err: true},
+		{name: "trailing data", wkt: "POINT (1 2) POINT (1 2)", err: true},
+		{name: "invalid srid", wkt: "SRID=4326 POINT (1 2)", err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o, err := ParseWKT(tc.wkt)
+			if err != nil {
+				if !tc.err {
+					t.Fatal(err)
+				}
+				return
+			}
+			if tc.err {
+				t.Fatalf("have nil, expected error")
+			}
+			if have := o.JSON(); have != tc.want {
+				t.Fatalf("have %s, want %s", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestToWKT(t *testing.T) {
+	res := 8
+	origin := h3.FromGeo(h3.GeoCoord{Latitude: 40.751389, Longitude: -74.143609}, res)
+	indexes := h3.KRing(origin, 2)
+	wkt, err := ToWKT(indexes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := ParseWKT(wkt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := o.(*geojson.MultiPolygon); !ok {
+		t.Fatalf("have %T, want *geojson.MultiPolygon", o)
+	}
+	have, err := ToH3(res, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameIndexes(t, indexes, have)
+
+	if _, err := ToWKT(nil); err == nil {
+		t.Fatalf("have nil, expected error")
+	}
+}
+
+func assertSameIndexes(t *testing.T, want, have []h3.H3Index) {
+	t.Helper()
+	if len(want) != len(have) {
+		t.Fatalf("have %d indexes, want %d", len(have), len(want))
+	}
+	want = append([]h3.H3Index(nil), want...)
+	have = append([]h3.H3Index(nil), have...)
+	sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+	sort.Slice(have, func(i, j int) bool { return have[i] < have[j] })
+	for i := range want {
+		if want[i] != have[i] {
+			t.Fatalf("have %s, want %s", h3.ToString(have[i]), h3.ToString(want[i]))
+		}
+	}
+}